@@ -0,0 +1,182 @@
+package convert
+
+import "github.com/sudokoin/sudoku/validate"
+
+// Canonical maps a board to the representative of all boards that only differ by
+// relabeling symbols, permuting rows within bands, columns within stacks,
+// permuting bands or stacks and transposing. Empty fields stay empty.
+// The representative is the lexicographically smallest of these boards, read row by row,
+// with symbols relabeled in order of appearance. Solved boards can thus be given
+// a unique id with ToBytes(Canonical(board)).
+// Boards containing invalid symbols are returned unchanged.
+func Canonical(board [9][9]int) [9][9]int {
+	if !validate.Symbols(board) {
+		return board
+	}
+	orders := lineOrders()
+	c := &canonicalSearch{}
+	for _, b := range [2][9][9]int{board, transpose(board)} {
+		c.findEqualRows(b)
+		for _, cols := range orders {
+			permuted := [9][9]int{}
+			for rowIdx, row := range b {
+				for colIdx, col := range cols {
+					permuted[rowIdx][colIdx] = row[col]
+				}
+			}
+			if swapsEqualCols(&permuted, &cols) {
+				continue
+			}
+			c.search(&permuted, [10]int{}, 1, 0, 0, !c.found)
+		}
+	}
+	canonical := [9][9]int{}
+	for idx, val := range c.best {
+		canonical[idx/9][idx%9] = val
+	}
+	return canonical
+}
+
+// canonicalSearch holds the smallest relabeled board found so far, read row by row.
+type canonicalSearch struct {
+	best  [81]int
+	found bool
+	// equalRows are the earlier rows of the same band equal to each row.
+	equalRows [9]uint
+}
+
+// findEqualRows fills equalRows for board. Permuting columns keeps equal rows equal.
+func (c *canonicalSearch) findEqualRows(board [9][9]int) {
+	for rowIdx := range board {
+		c.equalRows[rowIdx] = 0
+		for other := rowIdx / 3 * 3; other < rowIdx; other++ {
+			if board[other] == board[rowIdx] {
+				c.equalRows[rowIdx] |= 1 << uint(other)
+			}
+		}
+	}
+}
+
+// search tries all rows of board that may follow the ones in used at position depth
+// while keeping bands intact. labels maps symbols to their label, next is the next free one.
+// If replace is set, the best rows from depth on are outdated and get replaced.
+func (c *canonicalSearch) search(board *[9][9]int, labels [10]int, next int, depth int, used uint, replace bool) {
+	if depth == 9 {
+		c.found = true
+		return
+	}
+	rows, count := c.nextRows(depth, used)
+	for _, rowIdx := range rows[:count] {
+		rowLabels, rowNext := labels, next
+		rowReplace := replace
+		cmp := 0
+		for colIdx, val := range board[rowIdx] {
+			if val != 0 {
+				if rowLabels[val] == 0 {
+					rowLabels[val] = rowNext
+					rowNext++
+				}
+				val = rowLabels[val]
+			}
+			if !rowReplace {
+				if cmp = val - c.best[depth*9+colIdx]; cmp > 0 {
+					break
+				}
+				rowReplace = cmp < 0
+			}
+			if rowReplace {
+				c.best[depth*9+colIdx] = val
+			}
+		}
+		if cmp > 0 {
+			continue
+		}
+		c.search(board, rowLabels, rowNext, depth+1, used|1<<uint(rowIdx), rowReplace)
+		// the best rows are up to date now, so the following rows only replace smaller ones
+		replace = false
+	}
+}
+
+// nextRows returns the rows that may be placed at position depth after the rows in used.
+// The first row of a band may come from any band not used yet, the others from its band.
+// Rows equal to an unused earlier row of their band are left out since they lead to the same boards.
+func (c *canonicalSearch) nextRows(depth int, used uint) ([9]int, int) {
+	rows := [9]int{}
+	count := 0
+	for rowIdx := 0; rowIdx < 9; rowIdx++ {
+		if used&(1<<uint(rowIdx)) != 0 || c.equalRows[rowIdx]&^used != 0 {
+			continue
+		}
+		band := uint(7) << uint(rowIdx/3*3)
+		if (depth%3 == 0) == (used&band == 0) {
+			rows[count] = rowIdx
+			count++
+		}
+	}
+	return rows, count
+}
+
+// swapsEqualCols returns true iff the column order cols puts two equal columns of a stack
+// or two equal stacks of board in descending order. The ascending order leads to the same board.
+func swapsEqualCols(board *[9][9]int, cols *[9]int) bool {
+	equalCols := func(a, b int) bool {
+		for _, row := range board {
+			if row[a] != row[b] {
+				return false
+			}
+		}
+		return true
+	}
+	for a := 0; a < 9; a++ {
+		for b := a + 1; b < a/3*3+3; b++ {
+			if cols[a] > cols[b] && equalCols(a, b) {
+				return true
+			}
+		}
+	}
+	for a := 0; a < 9; a += 3 {
+		for b := a + 3; b < 9; b += 3 {
+			if cols[a] > cols[b] && equalCols(a, b) && equalCols(a+1, b+1) && equalCols(a+2, b+2) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Equivalent returns true iff both boards have the same canonical representative.
+func Equivalent(a, b [9][9]int) bool {
+	return Canonical(a) == Canonical(b)
+}
+
+func transpose(board [9][9]int) [9][9]int {
+	t := [9][9]int{}
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			t[colIdx][rowIdx] = val
+		}
+	}
+	return t
+}
+
+// lineOrders returns all 1296 orders of rows (or columns) that keep bands (stacks) intact.
+func lineOrders() [][9]int {
+	perms := [6][3]int{{0, 1, 2}, {0, 2, 1}, {1, 0, 2}, {1, 2, 0}, {2, 0, 1}, {2, 1, 0}}
+	orders := make([][9]int, 0, 1296)
+	for _, bands := range perms {
+		for _, p0 := range perms {
+			for _, p1 := range perms {
+				for _, p2 := range perms {
+					order := [9]int{}
+					for bIdx, inBand := range [3][3]int{p0, p1, p2} {
+						for lIdx, line := range inBand {
+							order[bIdx*3+lIdx] = bands[bIdx]*3 + line
+						}
+					}
+					orders = append(orders, order)
+				}
+			}
+		}
+	}
+	return orders
+}
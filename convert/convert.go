@@ -19,6 +19,7 @@ var (
 	bitMasks = [8]uint8{128, 64, 32, 16, 8, 4, 2, 1}
 
 	reShortNotation = regexp.MustCompile("([a-i][1-9][1-9])")
+	reShortTriple   = regexp.MustCompile("^[a-i][1-9][1-9]$")
 )
 
 // ToBytes converts a solved 9x9 sudoku board into a compact bit representation.
@@ -235,6 +236,8 @@ func extractCol(board [9][9]int, idx int) [9]int {
 const abc = "abcdefghi"
 
 // FromShort tries to parse provided short notation.
+// It is lenient and skips anything that is not a valid triple, later triples
+// overwrite earlier ones for the same field. See FromShortStrict.
 func FromShort(s string) [9][9]int {
 	board := [9][9]int{}
 	for _, triple := range reShortNotation.FindAllString(s, 81) {
@@ -246,6 +249,32 @@ func FromShort(s string) [9][9]int {
 	return board
 }
 
+// FromShortStrict parses provided short notation.
+// An error is returned for empty input, anything but triples like "a18",
+// and for fields assigned more than once.
+func FromShortStrict(s string) ([9][9]int, error) {
+	board := [9][9]int{}
+	if s == "" {
+		return [9][9]int{}, errors.New("empty short notation")
+	}
+	for pos := 0; pos < len(s); pos += 3 {
+		if pos+3 > len(s) {
+			return [9][9]int{}, errors.Errorf("incomplete triple %q at index %d", s[pos:], pos)
+		}
+		triple := s[pos : pos+3]
+		if !reShortTriple.MatchString(triple) {
+			return [9][9]int{}, errors.Errorf("invalid triple %q at index %d", triple, pos)
+		}
+		rowIdx := strings.IndexByte(abc, triple[0])
+		colIdx := int(triple[1] - '1')
+		if board[rowIdx][colIdx] != 0 {
+			return [9][9]int{}, errors.Errorf("field %s assigned again at index %d", triple[:2], pos)
+		}
+		board[rowIdx][colIdx] = int(triple[2] - '0')
+	}
+	return board, nil
+}
+
 // Short returns simple sudoku string representation, e.g. "a18b52".
 func ToShort(board [9][9]int) string {
 	var s string
@@ -276,3 +305,24 @@ func ToUltraShort(board [9][9]int) (string, error) {
 	}
 	return s, nil
 }
+
+// FromUltraShort converts the ultra short notation (see ToUltraShort) back to a correctly solved board.
+// The last row and column are computed from the others.
+// An error is returned iff the provided string is malformed.
+func FromUltraShort(s string) ([9][9]int, error) {
+	if len(s) != 64 {
+		return [9][9]int{}, errors.Errorf("expected 64 chars, got %d", len(s))
+	}
+	board := [9][9]int{}
+	for idx := 0; idx < len(s); idx++ {
+		if s[idx] < '1' || s[idx] > '9' {
+			return [9][9]int{}, errors.Errorf("invalid char %q at index %d", s[idx], idx)
+		}
+		board[idx/8][idx%8] = int(s[idx] - '0')
+	}
+	board = solveCols(solveRows(board))
+	if !validate.Solved(board) {
+		return [9][9]int{}, errors.New("ultra short notation leads to incorrect board")
+	}
+	return board, nil
+}
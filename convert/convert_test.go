@@ -3,6 +3,7 @@ package convert_test
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/sudokoin/sudoku/convert"
@@ -308,3 +309,332 @@ func TestUltraShort(t *testing.T) {
 		t.Errorf("Expected short notations to match:\n%s\n%s", expected, actual)
 	}
 }
+
+var fromLineTests = []struct {
+	id          string
+	in          string
+	out         [9][9]int
+	errExpected bool
+}{
+	{
+		id:  "line",
+		in:  "987654321654321987321987654896745213745213896213896745579468132468132579132579468",
+		out: working,
+	}, {
+		id:  "dots and zeros",
+		in:  "9876543216543219873219876548960452137452138962138967455794681324681325791325794.8",
+		out: with0AndEmpty,
+	}, {
+		id: "grid",
+		in: `
+987 | 654 | 321
+654 | 321 | 987
+321 | 987 | 654
+----+-----+----
+896 | 045 | 213
+745 | 213 | 896
+213 | 896 | 745
+----+-----+----
+579 | 468 | 132
+468 | 132 | 579
+132 | 579 | 4.8
+`,
+		out: with0AndEmpty,
+	}, {
+		id:          "too short",
+		in:          "98765432165432198732198765489674521374521389621389674557946813246813257913257946",
+		errExpected: true,
+	}, {
+		id:          "too long",
+		in:          "9876543216543219873219876548967452137452138962138967455794681324681325791325794688",
+		errExpected: true,
+	}, {
+		id:          "invalid character",
+		in:          "98765432165432198732198765489674521374521389621389674557946813246813257913257946x",
+		errExpected: true,
+	},
+}
+
+var with0AndEmpty = [9][9]int{
+	{9, 8, 7, 6, 5, 4, 3, 2, 1},
+	{6, 5, 4, 3, 2, 1, 9, 8, 7},
+	{3, 2, 1, 9, 8, 7, 6, 5, 4},
+	{8, 9, 6, 0, 4, 5, 2, 1, 3},
+	{7, 4, 5, 2, 1, 3, 8, 9, 6},
+	{2, 1, 3, 8, 9, 6, 7, 4, 5},
+	{5, 7, 9, 4, 6, 8, 1, 3, 2},
+	{4, 6, 8, 1, 3, 2, 5, 7, 9},
+	{1, 3, 2, 5, 7, 9, 4, 0, 8},
+}
+
+func TestFromLine(t *testing.T) {
+	for _, test := range fromLineTests {
+		out, err := convert.FromLine(test.in)
+		if test.errExpected != (err != nil) {
+			t.Errorf("unexpected error for %s:\n%v\n", test.id, err)
+		}
+		if !reflect.DeepEqual(test.out, out) {
+			t.Errorf("unexpected output for %s:\n%v\n%v\n", test.id, test.out, out)
+		}
+	}
+}
+
+func TestToLine(t *testing.T) {
+	expected := "987654321654321987321987654896.452137452138962138967455794681324681325791325794.8"
+	actual, err := convert.ToLine(with0AndEmpty)
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if expected != actual {
+		t.Errorf("Expected line notations to match:\n%s\n%s", expected, actual)
+	}
+	if _, err := convert.ToLine(with10); err == nil {
+		t.Errorf("Expected error for invalid symbols")
+	}
+}
+
+var fromShortStrictTests = []struct {
+	id          string
+	in          string
+	out         [9][9]int
+	errExpected bool
+	errContains string
+}{
+	{
+		id:  "short",
+		in:  convert.ToShort(with0),
+		out: with0,
+	}, {
+		id:  "single triple",
+		in:  "b52",
+		out: [9][9]int{{}, {0, 0, 0, 0, 2}},
+	}, {
+		id:          "empty",
+		in:          "",
+		errExpected: true,
+	}, {
+		id:          "garbage",
+		in:          "a18xyzb52",
+		errExpected: true,
+		errContains: "at index 3",
+	}, {
+		id:          "incomplete triple",
+		in:          "a18b5",
+		errExpected: true,
+		errContains: "incomplete triple \"b5\" at index 3",
+	}, {
+		id:          "single char",
+		in:          "a",
+		errExpected: true,
+		errContains: "incomplete triple \"a\" at index 0",
+	}, {
+		id:          "zero value",
+		in:          "a10",
+		errExpected: true,
+	}, {
+		id:          "field assigned twice",
+		in:          "a18b52a13",
+		errExpected: true,
+	},
+}
+
+func TestFromShortStrict(t *testing.T) {
+	for _, test := range fromShortStrictTests {
+		out, err := convert.FromShortStrict(test.in)
+		if test.errExpected != (err != nil) {
+			t.Errorf("unexpected error for %s:\n%v\n", test.id, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), test.errContains) {
+			t.Errorf("expected error for %s to contain %q, got %q", test.id, test.errContains, err)
+		}
+		if !reflect.DeepEqual(test.out, out) {
+			t.Errorf("unexpected output for %s:\n%v\n%v\n", test.id, test.out, out)
+		}
+	}
+}
+
+var fromUltraShortTests = []struct {
+	id          string
+	in          string
+	out         [9][9]int
+	errExpected bool
+}{
+	{
+		id:  "working",
+		in:  "9876543265432198321987658967452174521389213896745794681346813257",
+		out: working,
+	}, {
+		id:          "empty",
+		in:          "",
+		errExpected: true,
+	}, {
+		id:          "too long",
+		in:          "98765432654321983219876589674521745213892138967457946813468132571",
+		errExpected: true,
+	}, {
+		id:          "invalid char",
+		in:          "98765432654321983219876589674521745213892138967457946813468132a7",
+		errExpected: true,
+	}, {
+		id:          "zero",
+		in:          "9876543265432198321987658967452174521389213896745794681346813250",
+		errExpected: true,
+	}, {
+		id:          "incorrect board",
+		in:          "9876543265432198321987658967452174521389213896745794681346813275",
+		errExpected: true,
+	},
+}
+
+func TestFromUltraShort(t *testing.T) {
+	for _, test := range fromUltraShortTests {
+		out, err := convert.FromUltraShort(test.in)
+		if test.errExpected != (err != nil) {
+			t.Errorf("unexpected error for %s:\n%v\n", test.id, err)
+		}
+		if !reflect.DeepEqual(test.out, out) {
+			t.Errorf("unexpected output for %s:\n%v\n%v\n", test.id, test.out, out)
+		}
+	}
+
+	for _, board := range [][9][9]int{working, working9last, working9firstOf2Grids, workingIdeal9s} {
+		s, _ := convert.ToUltraShort(board)
+		out, err := convert.FromUltraShort(s)
+		if err != nil || out != board {
+			t.Errorf("expected round trip for %s:\n%v\n%v", s, board, err)
+		}
+	}
+}
+
+var puzzle = [9][9]int{
+	{0, 0, 3, 0, 0, 0, 0, 1, 0},
+	{0, 0, 0, 3, 0, 9, 0, 0, 0},
+	{6, 8, 0, 0, 0, 7, 0, 0, 0},
+	{2, 1, 5, 4, 0, 0, 0, 0, 0},
+	{0, 4, 0, 0, 0, 1, 0, 0, 6},
+	{0, 0, 0, 0, 0, 0, 0, 0, 3},
+	{0, 0, 1, 0, 8, 0, 7, 0, 0},
+	{0, 6, 0, 7, 0, 0, 0, 5, 0},
+	{0, 7, 0, 0, 1, 0, 9, 0, 0},
+}
+
+var toPuzzleBytesTests = []struct {
+	id          string
+	in          [9][9]int
+	size        int
+	errExpected bool
+}{
+	{id: "empty", in: emptyBoard, size: 11},
+	{id: "single clue", in: [9][9]int{{}, {}, {}, {}, {0, 0, 0, 0, 9}}, size: 11},
+	{id: "puzzle", in: puzzle, size: 21},
+	{id: "with 0", in: with0, size: 44},
+	{id: "working", in: working, size: 44},
+	{id: "with 10", in: with10, errExpected: true},
+	{id: "with -1", in: withMinus1, errExpected: true},
+}
+
+func TestPuzzleBytes(t *testing.T) {
+	for _, test := range toPuzzleBytesTests {
+		bytes, err := convert.ToPuzzleBytes(test.in)
+		if test.errExpected != (err != nil) {
+			t.Errorf("unexpected error for %s:\n%v\n", test.id, err)
+		}
+		if err != nil {
+			continue
+		}
+		if len(bytes) != test.size {
+			t.Errorf("unexpected size for %s: %d", test.id, len(bytes))
+		}
+		out, err := convert.FromPuzzleBytes(bytes)
+		if err != nil || out != test.in {
+			t.Errorf("expected round trip for %s:\n%v\n%v\n%v", test.id, test.in, out, err)
+		}
+	}
+}
+
+var fromPuzzleBytesTests = []struct {
+	id  string
+	in  []byte
+	out [9][9]int
+}{
+	{id: "empty bytes", in: []byte{}},
+	{id: "short bytes", in: []byte{255, 255, 255}},
+	{id: "missing values", in: []byte{255, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+	{id: "surplus bytes", in: []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+	{id: "value out of range", in: []byte{224, 0, 0, 0, 0, 0, 0, 0, 0, 0, 127, 224}},
+}
+
+func TestFromPuzzleBytesErrors(t *testing.T) {
+	for _, test := range fromPuzzleBytesTests {
+		out, err := convert.FromPuzzleBytes(test.in)
+		if err == nil {
+			t.Errorf("expected error for %s", test.id)
+		}
+		if !reflect.DeepEqual(test.out, out) {
+			t.Errorf("unexpected output for %s:\n%v\n%v\n", test.id, test.out, out)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	canonical := convert.Canonical(working)
+	if canonical[0] != [9]int{1, 2, 3, 4, 5, 6, 7, 8, 9} {
+		t.Errorf("expected first row to be relabeled:\n%v", canonical)
+	}
+	if convert.Canonical(canonical) != canonical {
+		t.Errorf("expected canonical board to be its own representative:\n%v", canonical)
+	}
+
+	swapBands := [9]int{3, 4, 5, 0, 1, 2, 6, 7, 8}
+	swapCols := [9]int{0, 2, 1, 3, 4, 5, 6, 7, 8}
+	for _, board := range [][9][9]int{working, with0} {
+		variant := [9][9]int{}
+		for rowIdx, row := range board {
+			for colIdx, val := range row {
+				// transpose, swap the first two bands, swap two columns of the first stack
+				// and relabel 1-9 by 9-1
+				if val != 0 {
+					val = 10 - val
+				}
+				variant[swapBands[colIdx]][swapCols[rowIdx]] = val
+			}
+		}
+		if !convert.Equivalent(board, variant) {
+			t.Errorf("expected boards to be equivalent:\n%v\n%v", board, variant)
+		}
+	}
+	if convert.Equivalent(working, working9firstOf2Grids) {
+		t.Errorf("expected boards not to be equivalent:\n%v\n%v", working, working9firstOf2Grids)
+	}
+	if convert.Equivalent(working, with0) {
+		t.Errorf("expected boards not to be equivalent:\n%v\n%v", working, with0)
+	}
+	single, moved := [9][9]int{}, [9][9]int{}
+	single[0][0], moved[4][7] = 1, 5
+	if !convert.Equivalent(single, moved) || convert.Canonical(single) != convert.Canonical([9][9]int{{1}}) {
+		t.Errorf("expected single clues to be equivalent:\n%v", convert.Canonical(moved))
+	}
+	sameRow, diagonal := [9][9]int{{1, 2}}, [9][9]int{{1}, {0, 2}}
+	if convert.Equivalent(sameRow, diagonal) {
+		t.Errorf("expected clues in one row not to be equivalent to clues in two rows")
+	}
+	if convert.Canonical([9][9]int{}) != ([9][9]int{}) {
+		t.Errorf("expected empty board to stay empty")
+	}
+	if convert.Canonical(with10) != with10 {
+		t.Errorf("expected invalid board to stay unchanged")
+	}
+}
+
+func BenchmarkCanonical(b *testing.B) {
+	for _, bm := range []struct {
+		name  string
+		board [9][9]int
+	}{{"solved", working}, {"puzzle", puzzle}, {"empty", [9][9]int{}}} {
+		b.Run(bm.name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				convert.Canonical(bm.board)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package convert
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"github.com/sudokoin/sudoku/validate"
+)
+
+// lineSeparators may be used in line notation to draw a grid.
+const lineSeparators = "|-+"
+
+// ToLine returns the common exchange format of 81 chars, row by row,
+// with '.' for empty fields, e.g. "98.65432165...".
+// An error is returned iff the board contains invalid symbols.
+func ToLine(board [9][9]int) (string, error) {
+	if !validate.Symbols(board) {
+		return "", errors.New("board contains invalid symbols")
+	}
+	var sb strings.Builder
+	for _, row := range board {
+		for _, val := range row {
+			if val == 0 {
+				sb.WriteByte('.')
+			} else {
+				sb.WriteByte(byte('0' + val))
+			}
+		}
+	}
+	return sb.String(), nil
+}
+
+// FromLine parses the line notation of ToLine. Empty fields may be '.' or '0'.
+// Whitespace and the grid separators '|', '-' and '+' are ignored,
+// so that multi line grids can be parsed as well.
+// An error is returned for other characters or if there are not exactly 81 fields.
+func FromLine(s string) ([9][9]int, error) {
+	board := [9][9]int{}
+	fIdx := 0
+	for pos, r := range s {
+		var val int
+		switch {
+		case unicode.IsSpace(r) || strings.ContainsRune(lineSeparators, r):
+			continue
+		case r == '.' || r == '0':
+			val = 0
+		case r >= '1' && r <= '9':
+			val = int(r - '0')
+		default:
+			return [9][9]int{}, errors.Errorf("invalid character %q at index %d", r, pos)
+		}
+		if fIdx >= 81 {
+			return [9][9]int{}, errors.Errorf("more than 81 fields, first surplus at index %d", pos)
+		}
+		board[fIdx/9][fIdx%9] = val
+		fIdx++
+	}
+	if fIdx < 81 {
+		return [9][9]int{}, errors.Errorf("expected 81 fields, got %d", fIdx)
+	}
+	return board, nil
+}
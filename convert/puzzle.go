@@ -0,0 +1,124 @@
+package convert
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sudokoin/sudoku/validate"
+)
+
+// ToPuzzleBytes converts a partially filled 9x9 sudoku board into a compact bit representation.
+// The first 81 bits mark the filled fields row by row.
+// Then follow the values of the filled fields, 1-9 converted to 0-8.
+// Each three values are packed as base 9 number into 10 bits, a remaining pair into 7 bits
+// and a remaining single value into 4 bits.
+// Size is thus 11 bytes for an empty board, 18 bytes for 17 clues, 23 bytes for 30 clues
+// and never more than 44 bytes.
+// An error is returned iff the provided board contains invalid symbols.
+func ToPuzzleBytes(board [9][9]int) ([]byte, error) {
+	if !validate.Symbols(board) {
+		return nil, errors.New("board contains invalid symbols")
+	}
+
+	w := &bitWriter{}
+	values := []uint{}
+	for _, row := range board {
+		for _, val := range row {
+			if val == 0 {
+				w.write(0, 1)
+			} else {
+				w.write(1, 1)
+				values = append(values, uint(val-1))
+			}
+		}
+	}
+	for idx := 0; idx < len(values); idx += 3 {
+		group := values[idx:minInt(idx+3, len(values))]
+		var packed uint
+		for _, v := range group {
+			packed = packed*9 + v
+		}
+		w.write(packed, groupWidths[len(group)])
+	}
+	return w.bytes, nil
+}
+
+// FromPuzzleBytes converts bytes (see ToPuzzleBytes) back to a partially filled board.
+// An error is returned iff the provided bytes are malformed.
+func FromPuzzleBytes(bytes []byte) ([9][9]int, error) {
+	if len(bytes) < puzzleByteSize(0) {
+		return [9][9]int{}, errors.New("not enough bytes")
+	}
+
+	r := &bitReader{bytes: bytes}
+	filled := []int{}
+	for fIdx := 0; fIdx < 81; fIdx++ {
+		if r.read(1) == 1 {
+			filled = append(filled, fIdx)
+		}
+	}
+	if len(bytes) != puzzleByteSize(len(filled)) {
+		return [9][9]int{}, errors.Errorf("expected %d bytes for %d clues, got %d",
+			puzzleByteSize(len(filled)), len(filled), len(bytes))
+	}
+
+	board := [9][9]int{}
+	for idx := 0; idx < len(filled); idx += 3 {
+		group := filled[idx:minInt(idx+3, len(filled))]
+		packed := r.read(groupWidths[len(group)])
+		for gIdx := len(group) - 1; gIdx >= 0; gIdx-- {
+			board[group[gIdx]/9][group[gIdx]%9] = int(packed%9) + 1
+			packed /= 9
+		}
+		if packed != 0 {
+			return [9][9]int{}, errors.Errorf("invalid values for clue %d", idx)
+		}
+	}
+	return board, nil
+}
+
+// groupWidths are the bits needed for 1, 2 or 3 base 9 digits.
+var groupWidths = [4]uint{0, 4, 7, 10}
+
+func puzzleByteSize(clues int) int {
+	bitSize := 81 + uint(clues/3)*groupWidths[3] + groupWidths[clues%3]
+	return byteSize(bitSize)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// bitWriter appends values bit by bit starting with the most significant one.
+type bitWriter struct {
+	bytes []byte
+	size  uint
+}
+
+func (w *bitWriter) write(v uint, width uint) {
+	for bitIdx := width; bitIdx > 0; bitIdx-- {
+		if w.size%8 == 0 {
+			w.bytes = append(w.bytes, 0)
+		}
+		bit := uint8(v>>(bitIdx-1)) & 1
+		w.bytes[w.size/8] |= bit << (7 - w.size%8)
+		w.size++
+	}
+}
+
+// bitReader reads values written by bitWriter.
+type bitReader struct {
+	bytes []byte
+	pos   uint
+}
+
+func (r *bitReader) read(width uint) uint {
+	var v uint
+	for ; width > 0; width-- {
+		bit := r.bytes[r.pos/8] >> (7 - r.pos%8) & 1
+		v = v<<1 | uint(bit)
+		r.pos++
+	}
+	return v
+}
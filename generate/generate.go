@@ -2,18 +2,67 @@
 package generate
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/sudokoin/sudoku/rate"
 	"github.com/sudokoin/sudoku/solve"
 )
 
+// Generator generates sudokus from its own source of randomness.
+// Generators created from the same seed produce the same sudokus.
+// A Generator is not safe for concurrent use.
+type Generator struct {
+	r        *rand.Rand
+	symmetry Symmetry
+}
+
+// New returns a Generator seeded with seed.
+func New(seed int64) *Generator {
+	return NewWithSource(rand.NewSource(seed))
+}
+
+// NewWithSource returns a Generator drawing its randomness from src.
+func NewWithSource(src rand.Source) *Generator {
+	return &Generator{r: rand.New(src)}
+}
+
+// SetSymmetry makes all further sudokus derived by g have clues in the given pattern.
+// Clues are then added and removed in groups of fields mapped onto each other.
+func (g *Generator) SetSymmetry(symmetry Symmetry) {
+	g.symmetry = symmetry
+}
+
 // Random generates a random solved sudoku.
 func Random() [9][9]int {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	return New(time.Now().UnixNano()).Random()
+}
+
+// SingleCandidate derives a sudoku that can be solved with single candidate strategy
+// from provided solved board.
+func SingleCandidate(board [9][9]int, minFields int) [9][9]int {
+	return New(time.Now().UnixNano()).SingleCandidate(board, minFields)
+}
+
+// Unique derives a sudoku with exactly one solution from provided solved board.
+// See Generator.Unique.
+func Unique(board [9][9]int, minimal bool) [9][9]int {
+	return New(time.Now().UnixNano()).Unique(board, minimal)
+}
+
+// Rated generates a uniquely solvable sudoku of the requested difficulty tier.
+// See Generator.Rated.
+func Rated(ctx context.Context, tier rate.Tier, maxAttempts int) ([9][9]int, error) {
+	return New(time.Now().UnixNano()).Rated(ctx, tier, maxAttempts)
+}
+
+// Random generates a random solved sudoku.
+func (g *Generator) Random() [9][9]int {
 	board := [9][9]int{}
-	copy(board[0][:], r.Perm(9))
+	copy(board[0][:], g.r.Perm(9))
 	for colIdx, _ := range board[0] {
 		board[0][colIdx]++
 	}
@@ -22,51 +71,196 @@ func Random() [9][9]int {
 	if !solved || l < 1 {
 		panic(fmt.Sprintf("unanticipated problem with solving board: %v\n", board))
 	}
-	return solutions[r.Intn(l)]
+	return solutions[g.r.Intn(l)]
 }
 
 // SingleCandidate derives a sudoku that can be solved with single candidate strategy
 // from provided solved board.
-func SingleCandidate(board [9][9]int, minFields int) [9][9]int {
+func (g *Generator) SingleCandidate(board [9][9]int, minFields int) [9][9]int {
 	if minFields < 0 || minFields > 80 {
 		minFields = 10 // minimum found sudoku is 17 right now, 10 is for safety.
 	}
-	fields := randomFields(board)
-	unsolved := fillTillMinimum(fields, minFields)
-	unsolved = fillTillSolvableSingleCandidate(unsolved, fields[minFields:])
+	orbits := g.randomOrbits(board)
+	unsolved, used := fillTillMinimum(orbits, minFields)
+	unsolved = fillTillSolvableSingleCandidate(unsolved, orbits[used:])
 	return unsolved
 }
 
-func fillTillSolvableSingleCandidate(board [9][9]int, fields [][3]int) [9][9]int {
+// Unique derives a sudoku with exactly one solution from provided solved board.
+// See Generator.Unique.
+func (g *Generator) Unique(board [9][9]int, minimal bool) [9][9]int {
+	for _, o := range g.randomOrbits(board) {
+		board = empty(board, o)
+		if !solve.HasUniqueSolution(board) {
+			board = fill(board, o)
+			if !minimal {
+				break
+			}
+		}
+	}
+	return board
+}
+
+// Rated generates a uniquely solvable sudoku of the requested difficulty tier.
+// Each attempt starts from a new random solved board and removes clues in random order
+// as long as the board stays uniquely solvable and does not exceed the tier.
+// An error is returned if no attempt lands in the tier within maxAttempts
+// or if ctx is done before; use a deadline on ctx to limit the time spent.
+func (g *Generator) Rated(ctx context.Context, tier rate.Tier, maxAttempts int) ([9][9]int, error) {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		board, err := g.pruneToTier(ctx, g.Random(), tier)
+		if err != nil {
+			return [9][9]int{}, err
+		}
+		if rating, _ := rate.Rate(board); rating.Tier == tier {
+			return board, nil
+		}
+	}
+	return [9][9]int{}, errors.Errorf("no %v sudoku found in %d attempts", tier, maxAttempts)
+}
+
+func (g *Generator) pruneToTier(ctx context.Context, board [9][9]int, tier rate.Tier) ([9][9]int, error) {
+	for _, o := range g.randomOrbits(board) {
+		if err := ctx.Err(); err != nil {
+			return [9][9]int{}, errors.Wrap(err, "generation aborted")
+		}
+		board = empty(board, o)
+		if !solve.HasUniqueSolution(board) {
+			board = fill(board, o)
+			continue
+		}
+		if rating, _ := rate.Rate(board); rating.Tier > tier {
+			board = fill(board, o)
+		}
+	}
+	return board, nil
+}
+
+func fillTillSolvableSingleCandidate(board [9][9]int, orbits [][][3]int) [9][9]int {
 	_, solved := solve.SolveSingleCandidate(board)
-	fIdx := 0
+	oIdx := 0
 	for !solved {
-		f := fields[fIdx]
-		board[f[0]][f[1]] = f[2]
-		fIdx++
+		board = fill(board, orbits[oIdx])
+		oIdx++
 		_, solved = solve.SolveSingleCandidate(board)
 	}
 	return board
 }
 
-func fillTillMinimum(fields [][3]int, minFields int) [9][9]int {
+// fillTillMinimum fills orbits till the board has at least minFields clues.
+// It returns the board and the number of orbits used.
+func fillTillMinimum(orbits [][][3]int, minFields int) ([9][9]int, int) {
 	board := [9][9]int{}
-	for fIdx, f := range fields {
-		if fIdx < minFields {
-			board[f[0]][f[1]] = f[2]
-		}
+	clues := 0
+	oIdx := 0
+	for ; oIdx < len(orbits) && clues < minFields; oIdx++ {
+		board = fill(board, orbits[oIdx])
+		clues += len(orbits[oIdx])
+	}
+	return board, oIdx
+}
+
+func fill(board [9][9]int, fields [][3]int) [9][9]int {
+	for _, f := range fields {
+		board[f[0]][f[1]] = f[2]
 	}
 	return board
 }
 
-func randomFields(board [9][9]int) [][3]int {
-	fields := [][3]int{}
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	rf := r.Perm(81)
-	for _, rIdx := range rf {
+func empty(board [9][9]int, fields [][3]int) [9][9]int {
+	for _, f := range fields {
+		board[f[0]][f[1]] = 0
+	}
+	return board
+}
+
+// randomOrbits returns all fields in random order, grouped by the symmetry of g.
+func (g *Generator) randomOrbits(board [9][9]int) [][][3]int {
+	orbits := [][][3]int{}
+	seen := [9][9]bool{}
+	for _, rIdx := range g.r.Perm(81) {
 		rowIdx := rIdx / 9
 		colIdx := rIdx % 9
-		fields = append(fields, [3]int{rowIdx, colIdx, board[rowIdx][colIdx]})
+		if seen[rowIdx][colIdx] {
+			continue
+		}
+		orbit := [][3]int{}
+		for _, f := range g.symmetry.orbit(rowIdx, colIdx) {
+			seen[f[0]][f[1]] = true
+			orbit = append(orbit, [3]int{f[0], f[1], board[f[0]][f[1]]})
+		}
+		orbits = append(orbits, orbit)
+	}
+	return orbits
+}
+
+// Masked generates a uniquely solvable sudoku with clues exactly where mask is true.
+// See Generator.Masked.
+func Masked(ctx context.Context, mask [9][9]bool, maxAttempts int) ([9][9]int, error) {
+	return New(time.Now().UnixNano()).Masked(ctx, mask, maxAttempts)
+}
+
+// maskedLimit is the most solutions Masked counts when comparing clues.
+const maskedLimit = 100
+
+// Masked generates a uniquely solvable sudoku with clues exactly where mask is true.
+// It starts from the masked fields of a random solved board and then changes one clue per
+// attempt to another symbol keeping the sudoku solvable. Changes are kept unless they lead
+// to more solutions, so the clues approach a unique solution step by step.
+// An error is returned if mask has less than 17 fields, which never suffices for
+// a unique solution, if no attempt leads to a unique solution within maxAttempts
+// or if ctx is done before; use a deadline on ctx to limit the time spent.
+func (g *Generator) Masked(ctx context.Context, mask [9][9]bool, maxAttempts int) ([9][9]int, error) {
+	fields := [][2]int{}
+	for rowIdx, row := range mask {
+		for colIdx, set := range row {
+			if set {
+				fields = append(fields, [2]int{rowIdx, colIdx})
+			}
+		}
+	}
+	if len(fields) < 17 {
+		return [9][9]int{}, errors.Errorf("mask with %d fields cannot have a unique solution", len(fields))
+	}
+	board := g.Random()
+	for rowIdx, row := range mask {
+		for colIdx, set := range row {
+			if !set {
+				board[rowIdx][colIdx] = 0
+			}
+		}
+	}
+	count := solve.CountSolutions(board, maskedLimit)
+	for attempt := 0; attempt < maxAttempts && count > 1; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return [9][9]int{}, errors.Wrap(err, "generation aborted")
+		}
+		changed, ok := g.changeClue(board, fields[g.r.Intn(len(fields))])
+		if !ok {
+			continue
+		}
+		if changedCount := solve.CountSolutions(changed, maskedLimit); changedCount <= count {
+			board, count = changed, changedCount
+		}
+	}
+	if count != 1 {
+		return [9][9]int{}, errors.Errorf("no uniquely solvable sudoku found for mask in %d attempts", maxAttempts)
+	}
+	return board, nil
+}
+
+// changeClue sets field f of board to a random other symbol that keeps board solvable.
+// It returns false iff there is no such symbol.
+func (g *Generator) changeClue(board [9][9]int, f [2]int) ([9][9]int, bool) {
+	current := board[f[0]][f[1]]
+	for _, val := range g.r.Perm(9) {
+		if val+1 == current {
+			continue
+		}
+		board[f[0]][f[1]] = val + 1
+		if solve.CountSolutions(board, 1) == 1 {
+			return board, true
+		}
 	}
-	return fields
+	return board, false
 }
@@ -1,8 +1,11 @@
 package generate
 
 import (
+	"context"
 	"testing"
 
+	"github.com/sudokoin/sudoku"
+	"github.com/sudokoin/sudoku/rate"
 	"github.com/sudokoin/sudoku/solve"
 )
 
@@ -26,3 +29,150 @@ func TestGenerate(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratorSeed(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		a, b := New(seed), New(seed)
+		solvedA, solvedB := a.Random(), b.Random()
+		if solvedA != solvedB {
+			t.Errorf("expected equal solved boards for seed %d:\n%v\n%v", seed, solvedA, solvedB)
+		}
+		unsolvedA, unsolvedB := a.SingleCandidate(solvedA, 20), b.SingleCandidate(solvedB, 20)
+		if unsolvedA != unsolvedB {
+			t.Errorf("expected equal unsolved boards for seed %d:\n%v\n%v", seed, unsolvedA, unsolvedB)
+		}
+	}
+}
+
+func TestUnique(t *testing.T) {
+	for seed := int64(0); seed < 3; seed++ {
+		g := New(seed)
+		solved := g.Random()
+		for _, minimal := range []bool{false, true} {
+			board := g.Unique(solved, minimal)
+			_, solutions := solve.Backtrack(board, 2)
+			if len(solutions) != 1 || solutions[0] != solved {
+				t.Errorf("expected unique solution for seed %d:\n%v", seed, board)
+			}
+			if !minimal {
+				continue
+			}
+			for rowIdx, row := range board {
+				for colIdx, val := range row {
+					if val == 0 {
+						continue
+					}
+					reduced := board
+					reduced[rowIdx][colIdx] = 0
+					if _, solutions := solve.Backtrack(reduced, 2); len(solutions) < 2 {
+						t.Errorf("expected clue at %d,%d to be required for seed %d:\n%v", rowIdx, colIdx, seed, board)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestRated(t *testing.T) {
+	for _, tier := range []rate.Tier{rate.Easy, rate.Medium} {
+		board, err := New(1).Rated(context.Background(), tier, 20)
+		if err != nil {
+			t.Errorf("unexpected error for %v: %v", tier, err)
+			continue
+		}
+		rating, _ := rate.Rate(board)
+		if rating.Tier != tier {
+			t.Errorf("expected %v sudoku, got %v:\n%v", tier, rating.Tier, board)
+		}
+		if !solve.HasUniqueSolution(board) {
+			t.Errorf("expected unique solution:\n%v", board)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := New(1).Rated(ctx, rate.Easy, 20); err == nil {
+		t.Errorf("expected error for cancelled context")
+	}
+}
+
+func TestSymmetry(t *testing.T) {
+	symmetries := []Symmetry{NoSymmetry, Rotational180, Rotational90, Horizontal, Vertical, Diagonal}
+	for _, symmetry := range symmetries {
+		g := New(1)
+		g.SetSymmetry(symmetry)
+		solved := g.Random()
+
+		board := g.Unique(solved, true)
+		if !symmetry.Symmetric(board) {
+			t.Errorf("expected %v symmetric clues:\n%v", symmetry, board)
+		}
+		if _, solutions := solve.Bitboard(board, 2); len(solutions) != 1 || solutions[0] != solved {
+			t.Errorf("expected unique solution for %v symmetry:\n%v", symmetry, board)
+		}
+
+		board = g.SingleCandidate(solved, 20)
+		if !symmetry.Symmetric(board) {
+			t.Errorf("expected %v symmetric clues:\n%v", symmetry, board)
+		}
+		if _, solved := solve.SolveSingleCandidate(board); !solved {
+			t.Errorf("expected board to be solvable for %v symmetry:\n%v", symmetry, board)
+		}
+	}
+
+	asymmetric := [9][9]int{}
+	asymmetric[0][1] = 1
+	for _, symmetry := range symmetries[1:] {
+		if symmetry.Symmetric(asymmetric) {
+			t.Errorf("expected clue at 0,1 alone not to be %v symmetric", symmetry)
+		}
+	}
+}
+
+func TestMasked(t *testing.T) {
+	// 24 clues of a unique puzzle, which random solved boards almost never fit
+	g := New(14)
+	g.SetSymmetry(Rotational180)
+	pattern := g.Unique(g.Random(), true)
+	if clues := sudoku.Board(pattern).Clues(); clues > 25 {
+		t.Fatalf("expected a mask of at most 25 fields, got %d", clues)
+	}
+	mask := [9][9]bool{}
+	for rowIdx, row := range pattern {
+		for colIdx, val := range row {
+			mask[rowIdx][colIdx] = val != 0
+		}
+	}
+	board, err := New(1).Masked(context.Background(), mask, 5000)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			if (val != 0) != mask[rowIdx][colIdx] {
+				t.Errorf("expected clues exactly at mask, differs at %d,%d:\n%v", rowIdx, colIdx, board)
+			}
+		}
+	}
+	if !solve.HasUniqueSolution(board) {
+		t.Errorf("expected unique solution:\n%v", board)
+	}
+
+	sparse := [9][9]bool{}
+	for colIdx := 0; colIdx < 9; colIdx++ {
+		sparse[0][colIdx] = true
+		sparse[1][colIdx] = colIdx < 7
+	}
+	if _, err := New(1).Masked(context.Background(), sparse, 10); err == nil {
+		t.Errorf("expected error for mask with 16 fields")
+	}
+	sparse[1][7] = true
+	if _, err := New(1).Masked(context.Background(), sparse, 10); err == nil {
+		t.Errorf("expected error for mask leaving 7 rows empty")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := New(1).Masked(ctx, mask, 10); err == nil {
+		t.Errorf("expected error for cancelled context")
+	}
+}
@@ -0,0 +1,68 @@
+package generate
+
+// Symmetry is a pattern the clues of generated sudokus follow.
+type Symmetry int
+
+const (
+	// NoSymmetry places clues anywhere.
+	NoSymmetry Symmetry = iota
+	// Rotational180 keeps clues when the board is turned by 180°.
+	Rotational180
+	// Rotational90 keeps clues when the board is turned by 90°.
+	Rotational90
+	// Horizontal mirrors clues between the top and bottom half.
+	Horizontal
+	// Vertical mirrors clues between the left and right half.
+	Vertical
+	// Diagonal mirrors clues along the main diagonal.
+	Diagonal
+)
+
+var symmetryNames = [...]string{"None", "Rotational 180°", "Rotational 90°", "Horizontal", "Vertical", "Diagonal"}
+
+func (s Symmetry) String() string {
+	if s < 0 || int(s) >= len(symmetryNames) {
+		return "Unknown"
+	}
+	return symmetryNames[s]
+}
+
+// image returns the field a field is mapped to by the symmetry.
+// Repeated application leads back to the original field.
+func (s Symmetry) image(rowIdx, colIdx int) (int, int) {
+	switch s {
+	case Rotational180:
+		return 8 - rowIdx, 8 - colIdx
+	case Rotational90:
+		return colIdx, 8 - rowIdx
+	case Horizontal:
+		return 8 - rowIdx, colIdx
+	case Vertical:
+		return rowIdx, 8 - colIdx
+	case Diagonal:
+		return colIdx, rowIdx
+	}
+	return rowIdx, colIdx
+}
+
+// Symmetric returns true iff the clues of board follow the symmetry.
+func (s Symmetry) Symmetric(board [9][9]int) bool {
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			imgRow, imgCol := s.image(rowIdx, colIdx)
+			if (val == 0) != (board[imgRow][imgCol] == 0) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// orbit returns all fields mapped onto each other by the symmetry, including the provided one.
+func (s Symmetry) orbit(rowIdx, colIdx int) [][2]int {
+	fields := [][2]int{{rowIdx, colIdx}}
+	for r, c := s.image(rowIdx, colIdx); r != rowIdx || c != colIdx; r, c = s.image(r, c) {
+		fields = append(fields, [2]int{r, c})
+	}
+	return fields
+}
@@ -0,0 +1,313 @@
+// Package grid contains helpers for sudokus of any size with rectangular boxes,
+// e.g. 4x4, 6x6 with 2x3 boxes, 12x12 with 3x4 boxes, 16x16 and 25x25.
+// For 9x9 sudokus the other packages accepting [9][9]int are faster.
+package grid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sudokoin/sudoku"
+)
+
+// Size describes a sudoku by the dimensions of its boxes.
+// A sudoku has BoxRows*BoxCols rows, columns, boxes and symbols.
+type Size struct {
+	BoxRows, BoxCols int
+}
+
+// Common sizes.
+var (
+	Size4  = Size{2, 2}
+	Size6  = Size{2, 3}
+	Size9  = Size{3, 3}
+	Size12 = Size{3, 4}
+	Size16 = Size{4, 4}
+	Size25 = Size{5, 5}
+)
+
+// maxN is the most symbols one alphabet provides.
+const maxN = 26
+
+// N returns the number of rows, columns, boxes and symbols.
+func (s Size) N() int {
+	return s.BoxRows * s.BoxCols
+}
+
+// Valid returns true iff the size is supported, i.e. both box dimensions are
+// positive and there are at most 26 symbols.
+func (s Size) Valid() bool {
+	return s.BoxRows > 0 && s.BoxCols > 0 && s.N() <= maxN
+}
+
+// Symbols returns the characters used to print values 1-N.
+// Up to 15 symbols are 1-9 followed by letters, 16 symbols are hex digits 0-F
+// and more symbols are letters starting with A.
+func (s Size) Symbols() string {
+	n := s.N()
+	switch {
+	case n <= 15:
+		return "123456789ABCDEF"[:n]
+	case n == 16:
+		return "0123456789ABCDEF"
+	}
+	return "ABCDEFGHIJKLMNOPQRSTUVWXYZ"[:n]
+}
+
+// Board is a sudoku of any Size. Empty fields are 0, filled fields hold a value of 1-N.
+type Board struct {
+	size   Size
+	fields []int
+}
+
+// NewBoard returns an empty board.
+// An error is returned iff the size is not valid.
+func NewBoard(size Size) (*Board, error) {
+	if !size.Valid() {
+		return nil, errors.Errorf("unsupported size %dx%d", size.BoxRows, size.BoxCols)
+	}
+	return &Board{size: size, fields: make([]int, size.N()*size.N())}, nil
+}
+
+// FromBoard converts a 9x9 board.
+func FromBoard(board [9][9]int) *Board {
+	b, _ := NewBoard(Size9)
+	for rowIdx, row := range board {
+		copy(b.fields[rowIdx*9:], row[:])
+	}
+	return b
+}
+
+// ToBoard converts back to a 9x9 board.
+// An error is returned iff the board has a different size.
+func (b *Board) ToBoard() (sudoku.Board, error) {
+	if b.size != Size9 {
+		return sudoku.Board{}, errors.Errorf("cannot convert %dx%d board", b.N(), b.N())
+	}
+	board := sudoku.Board{}
+	for idx, val := range b.fields {
+		board[idx/9][idx%9] = val
+	}
+	return board, nil
+}
+
+// Parse reads a board of the given size from its symbols row by row.
+// '.' marks empty fields, as does '0' unless it is a symbol. Whitespace and the
+// characters "|-+" are ignored, so the output of String can be parsed again.
+// An error is returned for invalid symbols or if the number of fields does not match.
+func Parse(size Size, s string) (*Board, error) {
+	b, err := NewBoard(size)
+	if err != nil {
+		return nil, err
+	}
+	symbols := size.Symbols()
+	idx := 0
+	for pos, char := range strings.ToUpper(s) {
+		if strings.ContainsRune(" \t\r\n|-+", char) {
+			continue
+		}
+		val := strings.IndexRune(symbols, char) + 1
+		if val == 0 && char != '.' && char != '0' {
+			return nil, errors.Errorf("invalid symbol %q at index %d", char, pos)
+		}
+		if idx >= len(b.fields) {
+			return nil, errors.Errorf("more than %d fields", len(b.fields))
+		}
+		b.fields[idx] = val
+		idx++
+	}
+	if idx < len(b.fields) {
+		return nil, errors.Errorf("expected %d fields, got %d", len(b.fields), idx)
+	}
+	return b, nil
+}
+
+// Size returns the size of the board.
+func (b *Board) Size() Size {
+	return b.size
+}
+
+// N returns the number of rows, columns, boxes and symbols.
+func (b *Board) N() int {
+	return b.size.N()
+}
+
+// Get returns the value of a field.
+// Like indexing an array, it panics if the position is out of range.
+func (b *Board) Get(row, col int) int {
+	b.checkIndex(row)
+	b.checkIndex(col)
+	return b.fields[row*b.N()+col]
+}
+
+// Set changes the value of a field. 0 empties it.
+// An error is returned iff the position or value is out of range.
+func (b *Board) Set(row, col, val int) error {
+	n := b.N()
+	if row < 0 || row >= n || col < 0 || col >= n {
+		return errors.Errorf("position %d,%d out of range", row, col)
+	}
+	if val < 0 || val > n {
+		return errors.Errorf("value %d out of range", val)
+	}
+	b.fields[row*n+col] = val
+	return nil
+}
+
+// Row returns the values of a row. It panics if idx is out of range.
+func (b *Board) Row(idx int) []int {
+	b.checkIndex(idx)
+	return b.group(b.size.rowCells(idx))
+}
+
+// Col returns the values of a column. It panics if idx is out of range.
+func (b *Board) Col(idx int) []int {
+	b.checkIndex(idx)
+	return b.group(b.size.colCells(idx))
+}
+
+// Box returns the values of a box. Boxes are numbered
+// from left to right and top to bottom. It panics if idx is out of range.
+func (b *Board) Box(idx int) []int {
+	b.checkIndex(idx)
+	return b.group(b.size.boxCells(idx))
+}
+
+// checkIndex panics unless idx is a valid row, column or box index.
+func (b *Board) checkIndex(idx int) {
+	if idx < 0 || idx >= b.N() {
+		panic(fmt.Sprintf("index %d out of range [0:%d]", idx, b.N()))
+	}
+}
+
+func (b *Board) group(cells []int) []int {
+	vals := make([]int, len(cells))
+	for cIdx, cell := range cells {
+		vals[cIdx] = b.fields[cell]
+	}
+	return vals
+}
+
+// Clues returns the number of filled fields.
+func (b *Board) Clues() int {
+	clues := 0
+	for _, val := range b.fields {
+		if val != 0 {
+			clues++
+		}
+	}
+	return clues
+}
+
+// Clone returns a copy of the board.
+func (b *Board) Clone() *Board {
+	return &Board{size: b.size, fields: append([]int(nil), b.fields...)}
+}
+
+// Equal returns true iff both boards have the same size and values.
+func (b *Board) Equal(other *Board) bool {
+	if b.size != other.size {
+		return false
+	}
+	for idx, val := range b.fields {
+		if other.fields[idx] != val {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the board as grid of N lines with '.' for empty fields
+// and values out of range.
+func (b *Board) String() string {
+	n := b.N()
+	symbols := b.size.Symbols()
+	lines := make([]string, 0, n+n/b.size.BoxRows)
+	for rowIdx := 0; rowIdx < n; rowIdx++ {
+		var sb strings.Builder
+		for colIdx, val := range b.Row(rowIdx) {
+			if colIdx > 0 && colIdx%b.size.BoxCols == 0 {
+				sb.WriteString(" |")
+			}
+			if colIdx > 0 {
+				sb.WriteString(" ")
+			}
+			if val < 1 || val > n {
+				sb.WriteString(".")
+			} else {
+				sb.WriteByte(symbols[val-1])
+			}
+		}
+		line := sb.String()
+		if rowIdx > 0 && rowIdx%b.size.BoxRows == 0 {
+			lines = append(lines, separator(line))
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// separator returns a line of dashes crossing the box borders of line.
+func separator(line string) string {
+	return strings.Map(func(char rune) rune {
+		if char == '|' {
+			return '+'
+		}
+		return '-'
+	}, line)
+}
+
+// rowCells returns the field indices of a row.
+func (s Size) rowCells(idx int) []int {
+	n := s.N()
+	cells := make([]int, n)
+	for cIdx := range cells {
+		cells[cIdx] = idx*n + cIdx
+	}
+	return cells
+}
+
+// colCells returns the field indices of a column.
+func (s Size) colCells(idx int) []int {
+	n := s.N()
+	cells := make([]int, n)
+	for cIdx := range cells {
+		cells[cIdx] = cIdx*n + idx
+	}
+	return cells
+}
+
+// boxCells returns the field indices of a box.
+func (s Size) boxCells(idx int) []int {
+	n := s.N()
+	boxesPerBand := n / s.BoxCols
+	top, left := idx/boxesPerBand*s.BoxRows, idx%boxesPerBand*s.BoxCols
+	cells := make([]int, n)
+	for cIdx := range cells {
+		cells[cIdx] = (top+cIdx/s.BoxCols)*n + left + cIdx%s.BoxCols
+	}
+	return cells
+}
+
+// units returns the field indices of all rows, columns and boxes.
+func (s Size) units() [][]int {
+	n := s.N()
+	units := make([][]int, 0, 3*n)
+	for idx := 0; idx < n; idx++ {
+		units = append(units, s.rowCells(idx))
+	}
+	for idx := 0; idx < n; idx++ {
+		units = append(units, s.colCells(idx))
+	}
+	for idx := 0; idx < n; idx++ {
+		units = append(units, s.boxCells(idx))
+	}
+	return units
+}
+
+// boxOf returns the box index of a field.
+func (s Size) boxOf(field int) int {
+	n := s.N()
+	return field/n/s.BoxRows*(n/s.BoxCols) + field%n/s.BoxCols
+}
@@ -0,0 +1,74 @@
+package grid
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Generator generates sudokus of any size from its own source of randomness.
+// Generators created from the same seed produce the same sudokus.
+// A Generator is not safe for concurrent use.
+type Generator struct {
+	r *rand.Rand
+}
+
+// NewGenerator returns a Generator seeded with seed.
+func NewGenerator(seed int64) *Generator {
+	return &Generator{r: rand.New(rand.NewSource(seed))}
+}
+
+// Random generates a random solved sudoku.
+// An error is returned iff the size is not valid.
+func Random(size Size) (*Board, error) {
+	return NewGenerator(time.Now().UnixNano()).Random(size)
+}
+
+// Unique derives a sudoku with exactly one solution from provided solved board.
+// See Generator.Unique.
+func Unique(board *Board, minimal bool) *Board {
+	return NewGenerator(time.Now().UnixNano()).Unique(board, minimal)
+}
+
+// Random generates a random solved sudoku.
+// An error is returned iff the size is not valid.
+func (g *Generator) Random(size Size) (*Board, error) {
+	board, err := NewBoard(size)
+	if err != nil {
+		return nil, err
+	}
+	n := size.N()
+	for {
+		s, _ := newSolver(board)
+		s.r = g.r
+		// restart instead of getting lost in unlucky early choices
+		s.maxNodes = 4 * n * n
+		solved := false
+		s.search(func() bool {
+			board, solved = s.board.Clone(), true
+			return true
+		})
+		if solved {
+			return board, nil
+		}
+	}
+}
+
+// Unique derives a sudoku with exactly one solution from provided solved board.
+// Clues are removed in random order until removing the next one would allow a second solution.
+// If minimal is true, all remaining clues are tried as well, so that no clue of the
+// returned sudoku can be removed without losing uniqueness.
+// The provided board stays unchanged.
+func (g *Generator) Unique(board *Board, minimal bool) *Board {
+	board = board.Clone()
+	for _, field := range g.r.Perm(len(board.fields)) {
+		val := board.fields[field]
+		board.fields[field] = 0
+		if !HasUniqueSolution(board) {
+			board.fields[field] = val
+			if !minimal {
+				break
+			}
+		}
+	}
+	return board
+}
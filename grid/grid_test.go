@@ -0,0 +1,163 @@
+package grid_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sudokoin/sudoku/grid"
+	"github.com/sudokoin/sudoku/solve"
+)
+
+var hard = [9][9]int{
+	{1, 0, 0, 0, 0, 7, 0, 9, 0},
+	{0, 3, 0, 0, 2, 0, 0, 0, 8},
+	{0, 0, 9, 6, 0, 0, 5, 0, 0},
+	{0, 0, 5, 3, 0, 0, 9, 0, 0},
+	{0, 1, 0, 0, 8, 0, 0, 0, 2},
+	{6, 0, 0, 0, 0, 4, 0, 0, 0},
+	{3, 0, 0, 0, 0, 0, 0, 1, 0},
+	{0, 4, 0, 0, 0, 0, 0, 0, 7},
+	{0, 0, 7, 0, 0, 0, 3, 0, 0},
+}
+
+const six = `1 2 3 | 4 5 6
+4 5 6 | 1 2 3
+------+------
+2 3 1 | 5 6 4
+5 6 4 | 2 3 1
+------+------
+3 1 2 | 6 4 5
+6 4 5 | 3 1 .
+`
+
+func ExampleBoard_String() {
+	board, _ := grid.NewBoard(grid.Size4)
+	board.Set(0, 0, 1)
+	board.Set(3, 3, 4)
+	fmt.Print(board)
+	// Output:
+	// 1 . | . .
+	// . . | . .
+	// ----+----
+	// . . | . .
+	// . . | . 4
+}
+
+func TestParse(t *testing.T) {
+	board, err := grid.Parse(grid.Size6, six)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if board.String() != six {
+		t.Errorf("expected parsed board to print as input, got:\n%v", board)
+	}
+	if box := board.Box(3); !reflect.DeepEqual(box, []int{5, 6, 4, 2, 3, 1}) {
+		t.Errorf("unexpected box 3: %v", box)
+	}
+	if col := board.Col(5); !reflect.DeepEqual(col, []int{6, 3, 4, 1, 5, 0}) {
+		t.Errorf("unexpected column 5: %v", col)
+	}
+	if board.Solved() || !board.Consistent() {
+		t.Errorf("expected consistent but unsolved board")
+	}
+	board.Set(5, 5, 2)
+	if !board.Solved() {
+		t.Errorf("expected solved board:\n%v", board)
+	}
+
+	hex, err := grid.Parse(grid.Size16, "0123456789abcdef"+strings.Repeat(".", 240))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if hex.Get(0, 0) != 1 || hex.Get(0, 15) != 16 || hex.Clues() != 16 {
+		t.Errorf("unexpected hex board:\n%v", hex)
+	}
+
+	for _, invalid := range []string{"", six + "1", "7" + six[1:], strings.Repeat("A", 625)} {
+		if _, err := grid.Parse(grid.Size6, invalid); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+	if _, err := grid.NewBoard(grid.Size{6, 5}); err == nil {
+		t.Errorf("expected error for 30 symbols")
+	}
+}
+
+func TestNineByNine(t *testing.T) {
+	board := grid.FromBoard(hard)
+	solutions := grid.Solve(board, 2)
+	_, expected := solve.Bitboard(hard, 2)
+	if len(solutions) != 1 || len(expected) != 1 {
+		t.Fatalf("expected one solution, got %d", len(solutions))
+	}
+	if solved, _ := solutions[0].ToBoard(); solved != expected[0] {
+		t.Errorf("expected solution\n%v\ngot\n%v", expected[0], solved)
+	}
+	if converted, _ := board.ToBoard(); converted != hard {
+		t.Errorf("expected conversion to keep the board")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	sizes := []grid.Size{grid.Size4, grid.Size6, grid.Size9, grid.Size12, grid.Size16, grid.Size25}
+	for _, size := range sizes {
+		g := grid.NewGenerator(1)
+		solved, err := g.Random(size)
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", size, err)
+		}
+		if !solved.Solved() {
+			t.Errorf("expected solved board:\n%v", solved)
+		}
+		board := g.Unique(solved, size.N() <= 9)
+		solutions := grid.Solve(board, 2)
+		if len(solutions) != 1 || !solutions[0].Equal(solved) {
+			t.Errorf("expected unique solution:\n%v", board)
+		}
+		if size.N() > 9 {
+			continue
+		}
+		for field := 0; field < size.N()*size.N(); field++ {
+			row, col := field/size.N(), field%size.N()
+			if val := board.Get(row, col); val != 0 {
+				board.Set(row, col, 0)
+				if grid.HasUniqueSolution(board) {
+					t.Errorf("expected clue at %d,%d to be required:\n%v", row, col, board)
+				}
+				board.Set(row, col, val)
+			}
+		}
+	}
+}
+
+func TestOutOfRange(t *testing.T) {
+	board, _ := grid.NewBoard(grid.Size4)
+	for name, access := range map[string]func(){
+		"get column": func() { board.Get(0, 4) },
+		"get row":    func() { board.Get(-1, 0) },
+		"row":        func() { board.Row(4) },
+		"column":     func() { board.Col(4) },
+		"box":        func() { board.Box(-1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected %s out of range to panic", name)
+				}
+			}()
+			access()
+		}()
+	}
+	if err := board.Set(0, 4, 1); err == nil {
+		t.Errorf("expected error for column out of range")
+	}
+}
+
+func TestInconsistent(t *testing.T) {
+	board, _ := grid.Parse(grid.Size4, "11.."+strings.Repeat(".", 12))
+	if board.Consistent() || grid.CountSolutions(board, 2) != 0 {
+		t.Errorf("expected no solutions for repeated value")
+	}
+}
@@ -0,0 +1,186 @@
+package grid
+
+import (
+	"math/bits"
+	"math/rand"
+)
+
+// Solve returns up to maxSolutions solutions of board, which stays unchanged.
+// Inconsistent boards have no solutions.
+func Solve(board *Board, maxSolutions int) []*Board {
+	solutions := []*Board{}
+	s, ok := newSolver(board)
+	if !ok || maxSolutions < 1 {
+		return solutions
+	}
+	s.search(func() bool {
+		solutions = append(solutions, s.board.Clone())
+		return len(solutions) >= maxSolutions
+	})
+	return solutions
+}
+
+// CountSolutions counts the solutions of board, but stops at limit.
+func CountSolutions(board *Board, limit int) int {
+	count := 0
+	s, ok := newSolver(board)
+	if !ok || limit < 1 {
+		return count
+	}
+	s.search(func() bool {
+		count++
+		return count >= limit
+	})
+	return count
+}
+
+// HasUniqueSolution returns true iff board has exactly one solution.
+func HasUniqueSolution(board *Board) bool {
+	return CountSolutions(board, 2) == 1
+}
+
+// solver fills a copy of a board by depth first search. Each step fills the field
+// with the fewest candidates or the only field left for a value in a row,
+// column or box, whichever has fewer options.
+type solver struct {
+	board *Board
+	units [][]int
+	// unitsOf are the row, column and box of each field.
+	unitsOf [][3]int
+	// taken are the values used in each unit.
+	taken []uint32
+	all   uint32
+	// r randomizes the order values are tried in, if set.
+	r *rand.Rand
+	// nodes counts the filled fields, maxNodes stops the search if positive.
+	nodes, maxNodes int
+}
+
+// newSolver returns a solver for a copy of board.
+// It returns false iff board is inconsistent.
+func newSolver(board *Board) (*solver, bool) {
+	if !board.Consistent() {
+		return nil, false
+	}
+	n := board.N()
+	s := &solver{
+		board:   board.Clone(),
+		units:   board.size.units(),
+		unitsOf: make([][3]int, n*n),
+		taken:   make([]uint32, 3*n),
+		all:     1<<uint(n) - 1,
+	}
+	for field := range s.unitsOf {
+		s.unitsOf[field] = [3]int{field / n, n + field%n, 2*n + board.size.boxOf(field)}
+	}
+	for field, val := range s.board.fields {
+		if val != 0 {
+			s.place(field, val)
+		}
+	}
+	return s, true
+}
+
+func (s *solver) place(field, val int) {
+	s.board.fields[field] = val
+	for _, u := range s.unitsOf[field] {
+		s.taken[u] |= toBit(val)
+	}
+}
+
+func (s *solver) unplace(field, val int) {
+	s.board.fields[field] = 0
+	for _, u := range s.unitsOf[field] {
+		s.taken[u] &^= toBit(val)
+	}
+}
+
+func (s *solver) candidates(field int) uint32 {
+	us := s.unitsOf[field]
+	return s.all &^ (s.taken[us[0]] | s.taken[us[1]] | s.taken[us[2]])
+}
+
+// search calls found for each solution until it returns true.
+// It returns true iff the search was stopped.
+func (s *solver) search(found func() bool) bool {
+	best, bestCands, bestCount := -1, uint32(0), 1<<30
+	for field, val := range s.board.fields {
+		if val != 0 {
+			continue
+		}
+		cands := s.candidates(field)
+		count := bits.OnesCount32(cands)
+		if count == 0 {
+			return false
+		}
+		if count < bestCount {
+			best, bestCands, bestCount = field, cands, count
+			if count == 1 {
+				break
+			}
+		}
+	}
+	if best == -1 {
+		return found()
+	}
+	if bestCount > 1 {
+		field, val, ok := s.hiddenSingle()
+		if !ok {
+			return false
+		}
+		if field != -1 {
+			best, bestCands = field, toBit(val)
+		}
+	}
+	return s.try(best, bestCands, found)
+}
+
+// hiddenSingle returns a field that is the only one left for a value in a unit,
+// or -1 if there is none. It returns false iff a value has no field left in a unit.
+func (s *solver) hiddenSingle() (int, int, bool) {
+	for u, unit := range s.units {
+		var once, twice uint32
+		for _, field := range unit {
+			if s.board.fields[field] == 0 {
+				cands := s.candidates(field)
+				twice |= once & cands
+				once |= cands
+			}
+		}
+		if s.all&^s.taken[u]&^once != 0 {
+			return -1, 0, false
+		}
+		if single := once &^ twice; single != 0 {
+			val := bits.TrailingZeros32(single) + 1
+			for _, field := range unit {
+				if s.board.fields[field] == 0 && s.candidates(field)&single&-single != 0 {
+					return field, val, true
+				}
+			}
+		}
+	}
+	return -1, 0, true
+}
+
+func (s *solver) try(field int, cands uint32, found func() bool) bool {
+	vals := make([]int, 0, bits.OnesCount32(cands))
+	for ; cands != 0; cands &= cands - 1 {
+		vals = append(vals, bits.TrailingZeros32(cands)+1)
+	}
+	if s.r != nil {
+		s.r.Shuffle(len(vals), func(i, j int) { vals[i], vals[j] = vals[j], vals[i] })
+	}
+	for _, val := range vals {
+		if s.maxNodes > 0 && s.nodes >= s.maxNodes {
+			return true
+		}
+		s.nodes++
+		s.place(field, val)
+		stop := s.search(found)
+		s.unplace(field, val)
+		if stop {
+			return true
+		}
+	}
+	return false
+}
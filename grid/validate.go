@@ -0,0 +1,43 @@
+package grid
+
+// Complete returns true iff all fields are filled.
+func (b *Board) Complete() bool {
+	for _, val := range b.fields {
+		if val == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Consistent returns true iff all values are in range and
+// no row, column or box contains a value twice.
+func (b *Board) Consistent() bool {
+	n := b.N()
+	for _, unit := range b.size.units() {
+		var taken uint32
+		for _, cell := range unit {
+			val := b.fields[cell]
+			if val < 0 || val > n {
+				return false
+			}
+			if val == 0 {
+				continue
+			}
+			if taken&toBit(val) != 0 {
+				return false
+			}
+			taken |= toBit(val)
+		}
+	}
+	return true
+}
+
+// Solved returns true iff the board is complete and consistent.
+func (b *Board) Solved() bool {
+	return b.Complete() && b.Consistent()
+}
+
+func toBit(val int) uint32 {
+	return 1 << uint(val-1)
+}
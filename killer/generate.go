@@ -0,0 +1,105 @@
+package killer
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sudokoin/sudoku/generate"
+)
+
+// Generator generates killer sudokus from its own source of randomness.
+// Generators created from the same seed produce the same sudokus.
+// A Generator is not safe for concurrent use.
+type Generator struct {
+	r      *rand.Rand
+	solved *generate.Generator
+}
+
+// New returns a Generator seeded with seed.
+func New(seed int64) *Generator {
+	r := rand.New(rand.NewSource(seed))
+	return &Generator{r: r, solved: generate.NewWithSource(r)}
+}
+
+// Generate generates a killer sudoku without clues, see Generator.Generate.
+func Generate(ctx context.Context, maxCageSize, maxAttempts int) ([9][9]int, []Cage, error) {
+	return New(time.Now().UnixNano()).Generate(ctx, maxCageSize, maxAttempts)
+}
+
+// Generate generates a killer sudoku without clues whose cages have a unique solution.
+// It returns the solution and the cages.
+// Each attempt carves cages of up to maxCageSize fields from a new random solved board.
+// An error is returned if no attempt is uniquely solvable within maxAttempts
+// or if ctx is done before; use a deadline on ctx to limit the time spent.
+func (g *Generator) Generate(ctx context.Context, maxCageSize, maxAttempts int) ([9][9]int, []Cage, error) {
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return [9][9]int{}, nil, errors.Wrap(err, "generation aborted")
+		}
+		solved := g.solved.Random()
+		cages := g.Carve(solved, maxCageSize)
+		count, stopped := countSolutions([9][9]int{}, cages, 2, ctx.Done())
+		if stopped {
+			return [9][9]int{}, nil, errors.Wrap(ctx.Err(), "generation aborted")
+		}
+		if count == 1 {
+			return solved, cages, nil
+		}
+	}
+	return [9][9]int{}, nil, errors.Errorf("no unique killer sudoku found in %d attempts", maxAttempts)
+}
+
+// Carve divides a solved board into cages of connected fields with distinct symbols.
+// Cages have random sizes between 1 and maxCageSize, which is limited to 1-9.
+// The cages might allow other solutions, see Generate.
+func (g *Generator) Carve(solved [9][9]int, maxCageSize int) []Cage {
+	if maxCageSize < 1 || maxCageSize > 9 {
+		maxCageSize = 9
+	}
+	caged := [9][9]bool{}
+	cages := []Cage{}
+	for _, idx := range g.r.Perm(81) {
+		start := Cell{idx / 9, idx % 9}
+		if caged[start.Row][start.Col] {
+			continue
+		}
+		size := 1 + g.r.Intn(maxCageSize)
+		cage := Cage{Cells: []Cell{start}, Sum: solved[start.Row][start.Col]}
+		caged[start.Row][start.Col] = true
+		taken := uint(1) << uint(solved[start.Row][start.Col])
+		for len(cage.Cells) < size {
+			next := []Cell{}
+			for _, cell := range cage.Cells {
+				for _, n := range neighbours(cell) {
+					if !caged[n.Row][n.Col] && taken&(1<<uint(solved[n.Row][n.Col])) == 0 {
+						next = append(next, n)
+					}
+				}
+			}
+			if len(next) == 0 {
+				break
+			}
+			n := next[g.r.Intn(len(next))]
+			caged[n.Row][n.Col] = true
+			taken |= 1 << uint(solved[n.Row][n.Col])
+			cage.Cells = append(cage.Cells, n)
+			cage.Sum += solved[n.Row][n.Col]
+		}
+		cages = append(cages, cage)
+	}
+	return cages
+}
+
+// neighbours returns the cells above, below, left and right of cell.
+func neighbours(cell Cell) []Cell {
+	cells := []Cell{}
+	for _, d := range [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+		row, col := cell.Row+d[0], cell.Col+d[1]
+		if row >= 0 && row < 9 && col >= 0 && col < 9 {
+			cells = append(cells, Cell{row, col})
+		}
+	}
+	return cells
+}
@@ -0,0 +1,119 @@
+// Package killer contains helpers for killer sudokus. Besides the usual rules,
+// the board is divided into cages whose fields must not repeat a symbol and
+// must add up to the sum of the cage.
+package killer
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/sudokoin/sudoku/validate"
+)
+
+const (
+	all uint = 1022 // bits 1-9 are set (1111111110)
+)
+
+// Cell is the position of a field on the board.
+type Cell struct {
+	Row, Col int
+}
+
+// Cage is a group of fields with distinct symbols adding up to Sum.
+type Cage struct {
+	Cells []Cell
+	Sum   int
+}
+
+// Combinations returns all sets of distinct symbols that fill the cage,
+// each in ascending order and sorted lexicographically.
+func (c Cage) Combinations() [][]int {
+	combos := [][]int{}
+	for _, combo := range c.combinations() {
+		vals := []int{}
+		for val := 1; val <= 9; val++ {
+			if combo&(1<<uint(val)) != 0 {
+				vals = append(vals, val)
+			}
+		}
+		combos = append(combos, vals)
+	}
+	sort.Slice(combos, func(i, j int) bool {
+		for idx := range combos[i] {
+			if combos[i][idx] != combos[j][idx] {
+				return combos[i][idx] < combos[j][idx]
+			}
+		}
+		return false
+	})
+	return combos
+}
+
+// combinations returns the symbol sets of the cage as bits 1-9.
+func (c Cage) combinations() []uint {
+	combos := []uint{}
+	for combo := uint(2); combo <= all; combo += 2 {
+		if bits.OnesCount(combo) == len(c.Cells) && sumOf(combo) == c.Sum {
+			combos = append(combos, combo)
+		}
+	}
+	return combos
+}
+
+func sumOf(combo uint) int {
+	sum := 0
+	for val := 1; val <= 9; val++ {
+		if combo&(1<<uint(val)) != 0 {
+			sum += val
+		}
+	}
+	return sum
+}
+
+// Check returns an error iff a cage has cells out of range, shares a cell with
+// another cage or cannot be filled with distinct symbols adding up to its sum.
+func Check(cages []Cage) error {
+	caged := [9][9]bool{}
+	for cIdx, cage := range cages {
+		for _, cell := range cage.Cells {
+			if cell.Row < 0 || cell.Row > 8 || cell.Col < 0 || cell.Col > 8 {
+				return errors.Errorf("cell %d,%d of cage %d out of range", cell.Row, cell.Col, cIdx)
+			}
+			if caged[cell.Row][cell.Col] {
+				return errors.Errorf("cell %d,%d of cage %d already caged", cell.Row, cell.Col, cIdx)
+			}
+			caged[cell.Row][cell.Col] = true
+		}
+		if len(cage.combinations()) == 0 {
+			return errors.Errorf("%d cells of cage %d cannot add up to %d", len(cage.Cells), cIdx, cage.Sum)
+		}
+	}
+	return nil
+}
+
+// Solved returns true iff board is solved correctly and fulfills all cages.
+func Solved(board [9][9]int, cages []Cage) bool {
+	if !validate.Solved(board) {
+		return false
+	}
+	for _, cage := range cages {
+		var taken uint
+		sum := 0
+		for _, cell := range cage.Cells {
+			if cell.Row < 0 || cell.Row > 8 || cell.Col < 0 || cell.Col > 8 {
+				return false
+			}
+			val := board[cell.Row][cell.Col]
+			if taken&(1<<uint(val)) != 0 {
+				return false
+			}
+			taken |= 1 << uint(val)
+			sum += val
+		}
+		if sum != cage.Sum {
+			return false
+		}
+	}
+	return true
+}
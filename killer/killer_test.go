@@ -0,0 +1,104 @@
+package killer_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sudokoin/sudoku/killer"
+)
+
+func TestCombinations(t *testing.T) {
+	tests := []struct {
+		cage     killer.Cage
+		expected [][]int
+	}{
+		{killer.Cage{Cells: make([]killer.Cell, 3), Sum: 6}, [][]int{{1, 2, 3}}},
+		{killer.Cage{Cells: make([]killer.Cell, 2), Sum: 10}, [][]int{{1, 9}, {2, 8}, {3, 7}, {4, 6}}},
+		{killer.Cage{Cells: make([]killer.Cell, 2), Sum: 2}, [][]int{}},
+	}
+	for _, test := range tests {
+		if combos := test.cage.Combinations(); !reflect.DeepEqual(combos, test.expected) {
+			t.Errorf("expected %v for %d cells adding up to %d, got %v", test.expected, len(test.cage.Cells), test.cage.Sum, combos)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		cages []killer.Cage
+		valid bool
+	}{
+		{[]killer.Cage{{Cells: []killer.Cell{{0, 0}, {0, 1}}, Sum: 3}, {Cells: []killer.Cell{{1, 0}}, Sum: 9}}, true},
+		{[]killer.Cage{{Cells: []killer.Cell{{0, 0}, {0, 1}}, Sum: 3}, {Cells: []killer.Cell{{0, 1}}, Sum: 9}}, false},
+		{[]killer.Cage{{Cells: []killer.Cell{{0, 9}}, Sum: 3}}, false},
+		{[]killer.Cage{{Cells: []killer.Cell{{0, 0}, {0, 1}}, Sum: 18}}, false},
+		{[]killer.Cage{{Sum: 0}}, false},
+	}
+	for _, test := range tests {
+		if err := killer.Check(test.cages); (err == nil) != test.valid {
+			t.Errorf("expected valid to be %v for %v, got error %v", test.valid, test.cages, err)
+		}
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	for seed := int64(0); seed < 3; seed++ {
+		solved, cages, err := killer.New(seed).Generate(context.Background(), 4, 20)
+		if err != nil {
+			t.Fatalf("unexpected error for seed %d: %v", seed, err)
+		}
+		if !killer.Solved(solved, cages) {
+			t.Errorf("expected generated board to fulfill cages for seed %d:\n%v", seed, solved)
+		}
+		if err := killer.Check(cages); err != nil {
+			t.Errorf("unexpected invalid cages for seed %d: %v", seed, err)
+		}
+		fields := 0
+		for _, cage := range cages {
+			if len(cage.Cells) > 4 {
+				t.Errorf("expected at most 4 cells, got %v", cage)
+			}
+			fields += len(cage.Cells)
+		}
+		if fields != 81 {
+			t.Errorf("expected cages to cover 81 fields, got %d", fields)
+		}
+		_, solutions := killer.Solve([9][9]int{}, cages, 2)
+		if len(solutions) != 1 || solutions[0] != solved {
+			t.Errorf("expected unique solution for seed %d, got %d", seed, len(solutions))
+		}
+
+		cages[0].Sum++
+		if killer.Solved(solved, cages) {
+			t.Errorf("expected changed cage sum to break the solution")
+		}
+		if killer.HasUniqueSolution([9][9]int{}, cages) {
+			t.Errorf("expected changed cage sum to break uniqueness")
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := killer.New(1).Generate(ctx, 4, 20); err == nil {
+		t.Errorf("expected error for cancelled context")
+	}
+}
+
+func TestSolveWithClues(t *testing.T) {
+	cages := []killer.Cage{{Cells: []killer.Cell{{0, 0}, {0, 1}}, Sum: 3}}
+	board := [9][9]int{}
+	board[0][0] = 2
+	_, solutions := killer.Solve(board, cages, 1)
+	if len(solutions) != 1 || solutions[0][0][1] != 1 || !killer.Solved(solutions[0], cages) {
+		t.Errorf("expected a solution with 1 next to clue 2, got %v", solutions)
+	}
+	board[0][0] = 3
+	if killer.CountSolutions(board, cages, 1) != 0 {
+		t.Errorf("expected no solution for clue exceeding cage sum")
+	}
+	board[0][0], board[0][1] = 1, 1
+	if killer.CountSolutions(board, nil, 1) != 0 {
+		t.Errorf("expected no solution for repeated clue")
+	}
+}
@@ -0,0 +1,231 @@
+package killer
+
+import (
+	"math/bits"
+
+	"github.com/sudokoin/sudoku/solve"
+	"github.com/sudokoin/sudoku/validate"
+)
+
+// Solve finds up to maxSolutions solutions of board respecting cages.
+// Provided clues are kept, so cages alone or together with clues can be solved.
+// It has the same contract as solve.Backtrack.
+// Invalid cages (see Check) and inconsistent boards have no solutions.
+func Solve(board [9][9]int, cages []Cage, maxSolutions int) (bool, [][9][9]int) {
+	solutions := [][9][9]int{}
+	if Check(cages) != nil || !validate.Consistent(board) {
+		return false, solutions
+	}
+	s := newSearch(cages)
+	done := s.backtrack(board, func(solution [9][9]int) bool {
+		solutions = append(solutions, solution)
+		return len(solutions) >= maxSolutions
+	})
+	return done, solutions
+}
+
+// CountSolutions counts the solutions of board respecting cages up to limit without keeping them.
+func CountSolutions(board [9][9]int, cages []Cage, limit int) int {
+	count, _ := countSolutions(board, cages, limit, nil)
+	return count
+}
+
+// countSolutions counts like CountSolutions until done is closed.
+// It returns true iff the search was stopped that way.
+func countSolutions(board [9][9]int, cages []Cage, limit int, done <-chan struct{}) (int, bool) {
+	count := 0
+	if Check(cages) != nil || !validate.Consistent(board) {
+		return count, false
+	}
+	s := newSearch(cages)
+	s.done = done
+	s.backtrack(board, func([9][9]int) bool {
+		count++
+		return count >= limit
+	})
+	return count, s.stopped
+}
+
+// HasUniqueSolution returns true iff board has exactly one solution respecting cages.
+func HasUniqueSolution(board [9][9]int, cages []Cage) bool {
+	return CountSolutions(board, cages, 2) == 1
+}
+
+// search backtracks like solve.Backtrack, but additionally restricts the candidates
+// of each field to the symbols still possible in its cage.
+type search struct {
+	cages []cage
+	// cageOf is the index of the cage of each field, or -1.
+	cageOf [9][9]int
+	// done stops the search once closed, a nil channel never does.
+	done    <-chan struct{}
+	stopped bool
+}
+
+type cage struct {
+	cells  []Cell
+	combos []uint
+}
+
+func newSearch(cages []Cage) *search {
+	s := &search{cages: make([]cage, len(cages))}
+	for rowIdx := range s.cageOf {
+		for colIdx := range s.cageOf[rowIdx] {
+			s.cageOf[rowIdx][colIdx] = -1
+		}
+	}
+	for cIdx, c := range cages {
+		s.cages[cIdx] = cage{cells: c.Cells, combos: c.combinations()}
+		for _, cell := range c.Cells {
+			s.cageOf[cell.Row][cell.Col] = cIdx
+		}
+	}
+	return s
+}
+
+// allowed returns the symbols that may still be placed in the empty fields of a cage:
+// those of all combinations containing the symbols already placed.
+func (s *search) allowed(board *[9][9]int, cIdx int) uint {
+	var placed uint
+	for _, cell := range s.cages[cIdx].cells {
+		if val := board[cell.Row][cell.Col]; val != 0 {
+			placed |= 1 << uint(val)
+		}
+	}
+	var allowed uint
+	for _, combo := range s.cages[cIdx].combos {
+		if combo&placed == placed {
+			allowed |= combo
+		}
+	}
+	return allowed &^ placed
+}
+
+// backtrack fills the field with the fewest candidates first.
+// It returns true iff found returned true for a solution or the search was stopped.
+func (s *search) backtrack(board [9][9]int, found func([9][9]int) bool) bool {
+	if s.stop() {
+		return true
+	}
+	cands := solve.NewCandidates(board)
+	cageAllowed := make([]uint, len(s.cages))
+	for cIdx := range s.cages {
+		cageAllowed[cIdx] = s.allowed(&board, cIdx)
+	}
+	bestRow, bestCol, bestCount := -1, -1, 10
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			if val != 0 {
+				continue
+			}
+			if cIdx := s.cageOf[rowIdx][colIdx]; cIdx != -1 {
+				cands[rowIdx][colIdx] &= cageAllowed[cIdx]
+			}
+			count := cands.Count(rowIdx, colIdx)
+			if count == 0 {
+				return false
+			}
+			if count < bestCount {
+				bestRow, bestCol, bestCount = rowIdx, colIdx, count
+			}
+		}
+	}
+	if bestRow == -1 {
+		if s.complete(&board) {
+			return found(board)
+		}
+		return false
+	}
+	if bestCount > 1 {
+		rowIdx, colIdx, val, ok := hiddenSingle(&board, &cands)
+		if !ok {
+			return false
+		}
+		if rowIdx != -1 {
+			board[rowIdx][colIdx] = val
+			return s.backtrack(board, found)
+		}
+	}
+	for _, val := range cands.Get(bestRow, bestCol) {
+		board[bestRow][bestCol] = val
+		if s.backtrack(board, found) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *search) stop() bool {
+	select {
+	case <-s.done:
+		s.stopped = true
+	default:
+	}
+	return s.stopped
+}
+
+// hiddenSingle returns a field that is the only one left for a symbol in a row, column
+// or block, or -1 if there is none. It returns false iff a symbol has no field left in a group.
+func hiddenSingle(board *[9][9]int, cands *solve.Candidates) (int, int, int, bool) {
+	for group := 0; group < 27; group++ {
+		var taken, once, twice uint
+		for idx := 0; idx < 9; idx++ {
+			rowIdx, colIdx := groupCell(group, idx)
+			if val := board[rowIdx][colIdx]; val != 0 {
+				taken |= 1 << uint(val)
+				continue
+			}
+			twice |= once & cands[rowIdx][colIdx]
+			once |= cands[rowIdx][colIdx]
+		}
+		if all&^taken&^once != 0 {
+			return -1, -1, 0, false
+		}
+		if single := once &^ twice; single != 0 {
+			val := bits.TrailingZeros(single)
+			for idx := 0; idx < 9; idx++ {
+				rowIdx, colIdx := groupCell(group, idx)
+				if board[rowIdx][colIdx] == 0 && cands.Has(rowIdx, colIdx, val) {
+					return rowIdx, colIdx, val, true
+				}
+			}
+		}
+	}
+	return -1, -1, 0, true
+}
+
+// groupCell returns the position of the idx-th field of a row (0-8), column (9-17) or block (18-26).
+func groupCell(group, idx int) (int, int) {
+	switch {
+	case group < 9:
+		return group, idx
+	case group < 18:
+		return idx, group - 9
+	}
+	block := group - 18
+	return block/3*3 + idx/3, block%3*3 + idx%3
+}
+
+// complete returns true iff all cages of a filled board add up to their sum,
+// which only fails for cages filled by clues.
+func (s *search) complete(board *[9][9]int) bool {
+	for cIdx, c := range s.cages {
+		var placed uint
+		for _, cell := range c.cells {
+			placed |= 1 << uint(board[cell.Row][cell.Col])
+		}
+		if bits.OnesCount(placed) != len(c.cells) || !containsCombo(s.cages[cIdx].combos, placed) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsCombo(combos []uint, placed uint) bool {
+	for _, combo := range combos {
+		if combo == placed {
+			return true
+		}
+	}
+	return false
+}
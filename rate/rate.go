@@ -0,0 +1,124 @@
+// Package rate contains helpers to rate the difficulty of 9x9 sudokus.
+package rate
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sudokoin/sudoku/solve"
+	"github.com/sudokoin/sudoku/validate"
+)
+
+// Tier is a named difficulty range.
+type Tier int
+
+// Tiers ordered by increasing difficulty.
+const (
+	Easy Tier = iota
+	Medium
+	Hard
+	Expert
+	Diabolical
+)
+
+var tierNames = [...]string{"Easy", "Medium", "Hard", "Expert", "Diabolical"}
+
+func (t Tier) String() string {
+	if t < 0 || int(t) >= len(tierNames) {
+		return "Unknown"
+	}
+	return tierNames[t]
+}
+
+// techniqueScores are Sudoku Explainer style ratings of the solve techniques.
+var techniqueScores = map[solve.Technique]float64{
+	solve.HiddenSingle:     1.5,
+	solve.NakedSingle:      2.3,
+	solve.PointingPair:     2.6,
+	solve.BoxLineReduction: 2.8,
+	solve.NakedPair:        3.0,
+	solve.XWing:            3.2,
+	solve.HiddenPair:       3.4,
+	solve.NakedTriple:      3.6,
+	solve.Swordfish:        3.8,
+	solve.HiddenTriple:     4.0,
+	solve.XYWing:           4.2,
+	solve.XYZWing:          4.4,
+	solve.NakedQuad:        5.0,
+	solve.Jellyfish:        5.2,
+	solve.HiddenQuad:       5.4,
+}
+
+const (
+	// stepScore is added for each step beyond singles.
+	stepScore = 0.02
+	// unsolvedScore is assigned to boards the solve techniques cannot solve.
+	unsolvedScore = 10
+)
+
+// tierLimits are the highest technique scores of each tier but Diabolical.
+// Steps do not count here, so any puzzle requiring a technique lands in the same tier.
+var tierLimits = [...]float64{2.3, 3.0, 4.0, 6.0}
+
+// Rating is the difficulty of a sudoku.
+type Rating struct {
+	// Score grows with difficulty, see Rate.
+	Score float64
+	Tier  Tier
+	// Hardest is the most difficult technique required.
+	Hardest solve.Technique
+	// Steps is the number of logical steps to solve the board.
+	Steps int
+	// Solved is false iff the board cannot be solved by logical techniques.
+	Solved bool
+}
+
+// Rate grades a board by the hardest technique solve.SolveLogically requires to solve it.
+// The tier only depends on that technique. The score starts with its rating and grows
+// slightly with every step beyond singles, so it orders puzzles within a tier.
+// Boards that cannot be solved logically are rated Diabolical with a score of 10.
+// An error is returned iff the board contains invalid symbols or does not have exactly one solution,
+// which includes boards with repeated symbols.
+func Rate(board [9][9]int) (Rating, error) {
+	if !validate.Symbols(board) {
+		return Rating{}, errors.New("board contains invalid symbols")
+	}
+	switch solve.CountSolutions(board, 2) {
+	case 0:
+		return Rating{}, errors.New("board has no solution")
+	case 2:
+		return Rating{}, errors.New("board has more than one solution")
+	}
+	_, steps, solved := solve.SolveLogically(board)
+	r := Rating{Hardest: hardest(steps), Steps: len(steps), Solved: solved}
+	if !solved {
+		r.Score = unsolvedScore
+		r.Tier = Diabolical
+		return r, nil
+	}
+	r.Score = techniqueScores[r.Hardest]
+	for _, step := range steps {
+		if step.Technique > solve.NakedSingle {
+			r.Score += stepScore
+		}
+	}
+	r.Tier = tierOf(r.Hardest)
+	return r, nil
+}
+
+func hardest(steps []solve.Step) solve.Technique {
+	h := solve.HiddenSingle
+	for _, step := range steps {
+		if step.Technique > h {
+			h = step.Technique
+		}
+	}
+	return h
+}
+
+func tierOf(technique solve.Technique) Tier {
+	for t, limit := range tierLimits {
+		if techniqueScores[technique] <= limit {
+			return Tier(t)
+		}
+	}
+	return Diabolical
+}
@@ -0,0 +1,138 @@
+package rate_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sudokoin/sudoku/rate"
+	"github.com/sudokoin/sudoku/solve"
+)
+
+func Example() {
+	board := [9][9]int{
+		{9, 0, 0, 1, 6, 0, 2, 7, 5},
+		{1, 0, 5, 0, 0, 0, 0, 0, 0},
+		{0, 7, 0, 0, 0, 9, 0, 0, 0},
+		{2, 0, 3, 4, 0, 0, 8, 0, 0},
+		{0, 5, 0, 0, 0, 0, 0, 0, 7},
+		{0, 0, 0, 0, 0, 0, 0, 0, 1},
+		{0, 6, 0, 0, 0, 0, 0, 4, 8},
+		{0, 0, 2, 9, 0, 0, 0, 0, 0},
+		{8, 0, 0, 0, 3, 0, 0, 6, 0},
+	}
+
+	rating, err := rate.Rate(board)
+
+	if err != nil {
+		// board contains invalid symbols
+	}
+
+	fmt.Println(rating.Tier, rating.Score)
+	// Output: Easy 1.5
+}
+
+var rateTests = []struct {
+	id          string
+	in          [9][9]int
+	tier        rate.Tier
+	hardest     solve.Technique
+	solved      bool
+	errExpected bool
+}{
+	{
+		id: "naked pair",
+		in: [9][9]int{
+			{0, 0, 3, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 9, 4, 6, 7},
+			{7, 0, 9, 0, 4, 0, 0, 0, 5},
+			{0, 1, 0, 0, 3, 0, 0, 8, 0},
+			{0, 5, 0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 7, 4, 0, 0, 3, 0, 0},
+			{5, 0, 1, 0, 0, 0, 9, 0, 0},
+			{0, 0, 0, 6, 1, 0, 0, 0, 3},
+			{0, 0, 0, 0, 2, 0, 0, 4, 0},
+		},
+		tier:    rate.Medium,
+		hardest: solve.NakedPair,
+		solved:  true,
+	}, {
+		id: "hidden triple",
+		in: [9][9]int{
+			{0, 4, 0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 6, 1, 7, 8, 0, 0, 0},
+			{0, 0, 0, 3, 0, 0, 0, 0, 6},
+			{0, 2, 3, 0, 0, 0, 0, 8, 0},
+			{0, 0, 5, 0, 0, 0, 0, 0, 0},
+			{0, 9, 7, 0, 6, 0, 4, 0, 0},
+			{0, 0, 0, 0, 0, 0, 9, 0, 2},
+			{0, 0, 0, 5, 0, 4, 0, 3, 0},
+			{9, 0, 0, 0, 1, 0, 0, 7, 4},
+		},
+		tier:    rate.Hard,
+		hardest: solve.HiddenTriple,
+		solved:  true,
+	}, {
+		id: "xy-wing",
+		in: [9][9]int{
+			{0, 0, 3, 0, 0, 0, 0, 1, 0},
+			{0, 0, 0, 3, 0, 9, 0, 0, 0},
+			{6, 8, 0, 0, 0, 7, 0, 0, 0},
+			{2, 1, 5, 4, 0, 0, 0, 0, 0},
+			{0, 4, 0, 0, 0, 1, 0, 0, 6},
+			{0, 0, 0, 0, 0, 0, 0, 0, 3},
+			{0, 0, 1, 0, 8, 0, 7, 0, 0},
+			{0, 6, 0, 7, 0, 0, 0, 5, 0},
+			{0, 7, 0, 0, 1, 0, 9, 0, 0},
+		},
+		tier:    rate.Expert,
+		hardest: solve.XYWing,
+		solved:  true,
+	}, {
+		id: "not logically solvable",
+		in: [9][9]int{
+			{0, 1, 0, 0, 0, 0, 0, 0, 0},
+			{0, 3, 0, 1, 6, 0, 0, 7, 9},
+			{0, 8, 0, 0, 0, 7, 0, 0, 3},
+			{0, 0, 0, 5, 4, 0, 0, 0, 0},
+			{0, 0, 6, 7, 0, 0, 0, 0, 0},
+			{7, 9, 0, 0, 0, 3, 0, 0, 0},
+			{3, 0, 0, 0, 0, 0, 9, 0, 4},
+			{8, 0, 0, 9, 0, 0, 0, 1, 0},
+			{0, 0, 0, 0, 2, 0, 0, 6, 0},
+		},
+		tier: rate.Diabolical,
+	}, {
+		id:          "invalid symbols",
+		in:          [9][9]int{{10}},
+		errExpected: true,
+	}, {
+		id:          "repeated symbols",
+		in:          [9][9]int{{1, 1}},
+		errExpected: true,
+	}, {
+		id:          "several solutions",
+		in:          [9][9]int{{1, 2, 3}},
+		errExpected: true,
+	},
+}
+
+func TestRate(t *testing.T) {
+	for _, test := range rateTests {
+		out, err := rate.Rate(test.in)
+		if test.errExpected != (err != nil) {
+			t.Errorf("unexpected error for %s:\n%v\n", test.id, err)
+		}
+		if err != nil {
+			continue
+		}
+		if test.tier != out.Tier {
+			t.Errorf("unexpected tier for %s:\n%v\n%v\n", test.id, test.tier, out.Tier)
+		}
+		if test.solved != out.Solved {
+			t.Errorf("unexpected solved bool for %s:\n%v\n%v\n", test.id, test.solved, out.Solved)
+		}
+		if test.solved && test.hardest != out.Hardest {
+			t.Errorf("unexpected hardest technique for %s:\n%v\n%v\n", test.id, test.hardest, out.Hardest)
+		}
+	}
+}
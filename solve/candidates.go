@@ -0,0 +1,52 @@
+package solve
+
+import "math/bits"
+
+// Candidates are the pencil marks of a board using the bit layout of the
+// single candidate strategy: bit n of a field is set iff symbol n may be placed there.
+// Filled fields have no candidates.
+type Candidates [9][9]uint
+
+// NewCandidates computes the candidates of all empty fields of a board.
+func NewCandidates(board [9][9]int) Candidates {
+	cands := Candidates(annotateSingleCandidate(board).fields)
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			if val != 0 {
+				cands[rowIdx][colIdx] = 0
+			}
+		}
+	}
+	return cands
+}
+
+// Has returns true iff val is a candidate of a field.
+func (c Candidates) Has(row, col, val int) bool {
+	return c[row][col]&toBit(val) != 0
+}
+
+// Get returns the candidates of a field in ascending order.
+func (c Candidates) Get(row, col int) []int {
+	return allSymbols(c[row][col])
+}
+
+// Count returns the number of candidates of a field.
+func (c Candidates) Count(row, col int) int {
+	return bits.OnesCount(c[row][col])
+}
+
+// Eliminate removes val from the candidates of a field.
+func (c *Candidates) Eliminate(row, col, val int) {
+	c[row][col] &^= toBit(val)
+}
+
+// Place removes all candidates of a field and val from the candidates
+// of all fields in its row, column and block.
+func (c *Candidates) Place(row, col, val int) {
+	c[row][col] = 0
+	for _, u := range [3]int{row, 9 + col, 18 + row/3*3 + col/3} {
+		for _, cell := range units[u] {
+			c.Eliminate(cell.Row, cell.Col, val)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package solve
+
+import (
+	"context"
+
+	"github.com/sudokoin/sudoku/validate"
+)
+
+// Result is what a cancellable solver found until it finished or was stopped.
+type Result struct {
+	// MaxReached is true iff the requested number of solutions was found.
+	MaxReached bool
+	Solutions  [][9][9]int
+	// Nodes is the number of visited search nodes.
+	Nodes int
+}
+
+// BacktrackContext is like Backtrack but stops as soon as ctx is done.
+// In that case the solutions found so far are returned together with ctx.Err().
+func BacktrackContext(ctx context.Context, board [9][9]int, maxSolutions int) (Result, error) {
+	s := &search{maxSolutions: maxSolutions, solutions: [][9][9]int{}, done: ctx.Done()}
+	s.backtrack(board)
+	res := Result{
+		MaxReached: len(s.solutions) >= maxSolutions,
+		Solutions:  s.solutions,
+		Nodes:      s.nodes,
+	}
+	if s.stopped {
+		return res, ctx.Err()
+	}
+	return res, nil
+}
+
+// SolveSingleCandidateContext is like SolveSingleCandidate but stops as soon as ctx is done.
+// In that case the partially solved board is returned together with ctx.Err().
+func SolveSingleCandidateContext(ctx context.Context, board [9][9]int) ([9][9]int, bool, error) {
+	next := annotateSingleCandidate(board).toBoard()
+	for board != next {
+		if err := ctx.Err(); err != nil {
+			return board, false, err
+		}
+		board = next
+		next = annotateSingleCandidate(board).toBoard()
+	}
+	return board, validate.Solved(board), nil
+}
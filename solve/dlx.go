@@ -0,0 +1,172 @@
+package solve
+
+// DancingLinks solves a board as exact cover problem with Knuth's Algorithm X.
+// It has the same contract as Backtrack but is considerably faster on hard boards.
+// Boards with symbols out of range or repeated symbols have no solutions.
+func DancingLinks(board [9][9]int, maxSolutions int) (bool, [][9][9]int) {
+	solutions := [][9][9]int{}
+	d := newDLX()
+	if !d.fill(board) {
+		return false, solutions
+	}
+	return d.search(board, maxSolutions, &solutions), solutions
+}
+
+const (
+	// dlxCols are the constraints: each field filled once, each symbol once per row, column and block.
+	dlxCols = 4 * 81
+	// dlxRows are all possible placements of 9 symbols in 81 fields.
+	dlxRows = 9 * 81
+	// dlxRoot is the node all column headers are linked to.
+	dlxRoot = 0
+)
+
+// dlx holds the toroidal doubly linked list of nodes in flat slices.
+// Node 0 is the root, nodes 1-324 are column headers and the rest are
+// the four nodes of each placement.
+type dlx struct {
+	left, right, up, down []int
+	col                   []int
+	size                  []int
+	// placement is the index of row*81 + col*9 + val-1 of each node.
+	placement []int
+	selected  []int
+}
+
+func newDLX() *dlx {
+	n := 1 + dlxCols + 4*dlxRows
+	d := &dlx{
+		left:      make([]int, n),
+		right:     make([]int, n),
+		up:        make([]int, n),
+		down:      make([]int, n),
+		col:       make([]int, n),
+		size:      make([]int, 1+dlxCols),
+		placement: make([]int, n),
+		selected:  make([]int, 0, 81),
+	}
+	for h := 0; h <= dlxCols; h++ {
+		d.left[h] = (h + dlxCols) % (dlxCols + 1)
+		d.right[h] = (h + 1) % (dlxCols + 1)
+		d.up[h] = h
+		d.down[h] = h
+		d.col[h] = h
+	}
+	node := dlxCols + 1
+	for p := 0; p < dlxRows; p++ {
+		first := node
+		for _, c := range placementCols(p) {
+			h := 1 + c
+			d.col[node] = h
+			d.placement[node] = p
+			d.up[node] = d.up[h]
+			d.down[node] = h
+			d.down[d.up[h]] = node
+			d.up[h] = node
+			d.size[h]++
+			d.left[node] = node - 1
+			d.right[node] = node + 1
+			node++
+		}
+		d.left[first] = node - 1
+		d.right[node-1] = first
+	}
+	return d
+}
+
+// placementCols returns the constraint columns satisfied by placement p.
+func placementCols(p int) [4]int {
+	rowIdx, colIdx, v := p/81, p/9%9, p%9
+	return [4]int{
+		rowIdx*9 + colIdx,
+		81 + rowIdx*9 + v,
+		162 + colIdx*9 + v,
+		243 + (rowIdx/3*3+colIdx/3)*9 + v,
+	}
+}
+
+func (d *dlx) cover(h int) {
+	d.right[d.left[h]] = d.right[h]
+	d.left[d.right[h]] = d.left[h]
+	for i := d.down[h]; i != h; i = d.down[i] {
+		for j := d.right[i]; j != i; j = d.right[j] {
+			d.down[d.up[j]] = d.down[j]
+			d.up[d.down[j]] = d.up[j]
+			d.size[d.col[j]]--
+		}
+	}
+}
+
+func (d *dlx) uncover(h int) {
+	for i := d.up[h]; i != h; i = d.up[i] {
+		for j := d.left[i]; j != i; j = d.left[j] {
+			d.size[d.col[j]]++
+			d.down[d.up[j]] = j
+			d.up[d.down[j]] = j
+		}
+	}
+	d.right[d.left[h]] = h
+	d.left[d.right[h]] = h
+}
+
+// fill covers the columns of all filled fields.
+// It returns false iff the board is contradictory.
+func (d *dlx) fill(board [9][9]int) bool {
+	covered := [dlxCols]bool{}
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			if val == 0 {
+				continue
+			}
+			if val < 0 || val > 9 {
+				return false
+			}
+			for _, c := range placementCols(rowIdx*81 + colIdx*9 + val - 1) {
+				if covered[c] {
+					return false
+				}
+				covered[c] = true
+				d.cover(1 + c)
+			}
+		}
+	}
+	return true
+}
+
+func (d *dlx) search(board [9][9]int, maxSolutions int, solutions *[][9][9]int) bool {
+	if d.right[dlxRoot] == dlxRoot {
+		solution := board
+		for _, p := range d.selected {
+			solution[p/81][p/9%9] = p%9 + 1
+		}
+		*solutions = append(*solutions, solution)
+		return len(*solutions) >= maxSolutions
+	}
+	h := d.right[dlxRoot]
+	for c := d.right[h]; c != dlxRoot; c = d.right[c] {
+		if d.size[c] < d.size[h] {
+			h = c
+		}
+	}
+	if d.size[h] == 0 {
+		return false
+	}
+	d.cover(h)
+	for r := d.down[h]; r != h; r = d.down[r] {
+		d.selected = append(d.selected, d.placement[r])
+		for j := d.right[r]; j != r; j = d.right[j] {
+			d.cover(d.col[j])
+		}
+		done := d.search(board, maxSolutions, solutions)
+		for j := d.left[r]; j != r; j = d.left[j] {
+			d.uncover(d.col[j])
+		}
+		d.selected = d.selected[:len(d.selected)-1]
+		if done {
+			d.uncover(h)
+			return true
+		}
+	}
+	d.uncover(h)
+	return false
+}
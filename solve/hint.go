@@ -0,0 +1,28 @@
+package solve
+
+// Hint returns the easiest logical step that can be applied to a board next.
+// The returned bool is false iff no technique of SolveLogically applies.
+func Hint(board [9][9]int) (Step, bool) {
+	return newLogical(board).next()
+}
+
+// HintWithCandidates is like Hint but starts from the pencil marks of a player.
+// Marks that contradict the filled fields of board are ignored, while marks
+// a player has already eliminated stay eliminated. Empty fields without marks
+// count as not pencilled yet and keep all their computed candidates.
+// The returned bool is false as well if the marks leave an empty field without candidates.
+func HintWithCandidates(board [9][9]int, marks Candidates) (Step, bool) {
+	l := newLogical(board)
+	for rowIdx, row := range marks {
+		for colIdx, m := range row {
+			if board[rowIdx][colIdx] != 0 || m == 0 {
+				continue
+			}
+			l.cands[rowIdx][colIdx] &= m
+			if l.cands[rowIdx][colIdx] == 0 {
+				return Step{}, false
+			}
+		}
+	}
+	return l.next()
+}
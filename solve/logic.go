@@ -0,0 +1,496 @@
+package solve
+
+import (
+	"math/bits"
+
+	"github.com/sudokoin/sudoku/validate"
+)
+
+// Technique is a logical solving technique.
+// Techniques are ordered by increasing difficulty.
+type Technique int
+
+// Techniques applied by SolveLogically.
+const (
+	HiddenSingle Technique = iota
+	NakedSingle
+	PointingPair // also covers pointing triples
+	BoxLineReduction
+	NakedPair
+	XWing
+	HiddenPair
+	NakedTriple
+	Swordfish
+	HiddenTriple
+	XYWing
+	XYZWing
+	NakedQuad
+	Jellyfish
+	HiddenQuad
+)
+
+var techniqueNames = [...]string{
+	"Hidden Single",
+	"Naked Single",
+	"Pointing Pair",
+	"Box/Line Reduction",
+	"Naked Pair",
+	"X-Wing",
+	"Hidden Pair",
+	"Naked Triple",
+	"Swordfish",
+	"Hidden Triple",
+	"XY-Wing",
+	"XYZ-Wing",
+	"Naked Quad",
+	"Jellyfish",
+	"Hidden Quad",
+}
+
+func (t Technique) String() string {
+	if t < 0 || int(t) >= len(techniqueNames) {
+		return "Unknown"
+	}
+	return techniqueNames[t]
+}
+
+// Cell is the position of a field on the board.
+type Cell struct {
+	Row, Col int
+}
+
+// Candidate is a symbol that may be placed in a cell.
+type Candidate struct {
+	Cell
+	Val int
+}
+
+// Step is a single logical deduction.
+type Step struct {
+	Technique Technique
+	// Cells form the pattern the deduction is based on.
+	Cells []Cell
+	// Placed is the symbol placed by a single. Its Val is 0 for eliminating steps.
+	Placed Candidate
+	// Eliminated are the candidates removed by the step.
+	Eliminated []Candidate
+}
+
+// SolveLogically tries to solve a board the way a human would, applying the easiest
+// applicable technique until the board is solved or no technique makes progress.
+// It returns the resulting board and the steps taken in order.
+// The returned bool indicates whether it was successful.
+func SolveLogically(board [9][9]int) ([9][9]int, []Step, bool) {
+	l := newLogical(board)
+	steps := []Step{}
+	for {
+		step, found := l.next()
+		if !found {
+			break
+		}
+		l.apply(step)
+		steps = append(steps, step)
+	}
+	return l.board, steps, validate.Solved(l.board)
+}
+
+// units holds all rows (0-8), columns (9-17) and blocks (18-26).
+var units = buildUnits()
+
+func buildUnits() [27][9]Cell {
+	u := [27][9]Cell{}
+	for i := 0; i < 9; i++ {
+		for j := 0; j < 9; j++ {
+			u[i][j] = Cell{i, j}
+			u[9+i][j] = Cell{j, i}
+			u[18+i][j] = Cell{i/3*3 + j/3, i%3*3 + j%3}
+		}
+	}
+	return u
+}
+
+func blockOf(c Cell) int {
+	return c.Row/3*3 + c.Col/3
+}
+
+func sees(a, b Cell) bool {
+	return a != b && (a.Row == b.Row || a.Col == b.Col || blockOf(a) == blockOf(b))
+}
+
+// logical is a board together with the candidates of its empty fields.
+type logical struct {
+	board [9][9]int
+	cands Candidates
+}
+
+func newLogical(board [9][9]int) *logical {
+	return &logical{board: board, cands: NewCandidates(board)}
+}
+
+func (l *logical) has(c Cell, val int) bool {
+	return l.cands.Has(c.Row, c.Col, val)
+}
+
+func (l *logical) apply(step Step) {
+	if step.Placed.Val != 0 {
+		l.board[step.Placed.Row][step.Placed.Col] = step.Placed.Val
+		l.cands.Place(step.Placed.Row, step.Placed.Col, step.Placed.Val)
+	}
+	for _, e := range step.Eliminated {
+		l.cands.Eliminate(e.Row, e.Col, e.Val)
+	}
+}
+
+// next finds the easiest applicable step.
+func (l *logical) next() (Step, bool) {
+	finders := [...]func() (Step, bool){
+		l.hiddenSingle,
+		l.nakedSingle,
+		l.pointing,
+		l.boxLineReduction,
+		func() (Step, bool) { return l.nakedSubset(2, NakedPair) },
+		func() (Step, bool) { return l.fish(2, XWing) },
+		func() (Step, bool) { return l.hiddenSubset(2, HiddenPair) },
+		func() (Step, bool) { return l.nakedSubset(3, NakedTriple) },
+		func() (Step, bool) { return l.fish(3, Swordfish) },
+		func() (Step, bool) { return l.hiddenSubset(3, HiddenTriple) },
+		l.xyWing,
+		l.xyzWing,
+		func() (Step, bool) { return l.nakedSubset(4, NakedQuad) },
+		func() (Step, bool) { return l.fish(4, Jellyfish) },
+		func() (Step, bool) { return l.hiddenSubset(4, HiddenQuad) },
+	}
+	for _, find := range finders {
+		if step, found := find(); found {
+			return step, true
+		}
+	}
+	return Step{}, false
+}
+
+// cellsWith returns the cells of unit u having val as candidate.
+func (l *logical) cellsWith(u int, val int) []Cell {
+	cells := []Cell{}
+	for _, c := range units[u] {
+		if l.has(c, val) {
+			cells = append(cells, c)
+		}
+	}
+	return cells
+}
+
+func (l *logical) hiddenSingle() (Step, bool) {
+	// blocks first since those are the easiest to spot
+	for _, u := range [27]int{18, 19, 20, 21, 22, 23, 24, 25, 26, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17} {
+		for val := 1; val <= 9; val++ {
+			if cells := l.cellsWith(u, val); len(cells) == 1 {
+				return Step{
+					Technique: HiddenSingle,
+					Cells:     cells,
+					Placed:    Candidate{cells[0], val},
+				}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+func (l *logical) nakedSingle() (Step, bool) {
+	for rowIdx, row := range l.cands {
+		for colIdx, cands := range row {
+			if syms := allSymbols(cands); len(syms) == 1 {
+				c := Cell{rowIdx, colIdx}
+				return Step{
+					Technique: NakedSingle,
+					Cells:     []Cell{c},
+					Placed:    Candidate{c, syms[0]},
+				}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+// eliminate collects val as elimination for all cells of unit u that are not excluded.
+func (l *logical) eliminate(u int, val int, excluded func(Cell) bool) []Candidate {
+	eliminated := []Candidate{}
+	for _, c := range units[u] {
+		if !excluded(c) && l.has(c, val) {
+			eliminated = append(eliminated, Candidate{c, val})
+		}
+	}
+	return eliminated
+}
+
+func (l *logical) pointing() (Step, bool) {
+	for b := 0; b < 9; b++ {
+		for val := 1; val <= 9; val++ {
+			cells := l.cellsWith(18+b, val)
+			if len(cells) < 2 {
+				continue
+			}
+			inBlock := func(c Cell) bool { return blockOf(c) == b }
+			var eliminated []Candidate
+			if sameRow(cells) {
+				eliminated = l.eliminate(cells[0].Row, val, inBlock)
+			} else if sameCol(cells) {
+				eliminated = l.eliminate(9+cells[0].Col, val, inBlock)
+			}
+			if len(eliminated) > 0 {
+				return Step{Technique: PointingPair, Cells: cells, Eliminated: eliminated}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+func (l *logical) boxLineReduction() (Step, bool) {
+	for u := 0; u < 18; u++ {
+		for val := 1; val <= 9; val++ {
+			cells := l.cellsWith(u, val)
+			if len(cells) < 2 || !sameBlock(cells) {
+				continue
+			}
+			inLine := func(c Cell) bool {
+				if u < 9 {
+					return c.Row == u
+				}
+				return c.Col == u-9
+			}
+			eliminated := l.eliminate(18+blockOf(cells[0]), val, inLine)
+			if len(eliminated) > 0 {
+				return Step{Technique: BoxLineReduction, Cells: cells, Eliminated: eliminated}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+func sameRow(cells []Cell) bool {
+	for _, c := range cells {
+		if c.Row != cells[0].Row {
+			return false
+		}
+	}
+	return true
+}
+
+func sameCol(cells []Cell) bool {
+	for _, c := range cells {
+		if c.Col != cells[0].Col {
+			return false
+		}
+	}
+	return true
+}
+
+func sameBlock(cells []Cell) bool {
+	for _, c := range cells {
+		if blockOf(c) != blockOf(cells[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+// nakedSubset finds n cells of a unit sharing exactly n candidates.
+func (l *logical) nakedSubset(n int, t Technique) (Step, bool) {
+	for u := range units {
+		cells := []Cell{}
+		for _, c := range units[u] {
+			if cnt := l.cands.Count(c.Row, c.Col); cnt > 1 && cnt <= n {
+				cells = append(cells, c)
+			}
+		}
+		for _, combo := range combinations(len(cells), n) {
+			var union uint
+			subset := make([]Cell, n)
+			for idx, cIdx := range combo {
+				subset[idx] = cells[cIdx]
+				union |= l.cands[cells[cIdx].Row][cells[cIdx].Col]
+			}
+			if bits.OnesCount(union) != n {
+				continue
+			}
+			inSubset := func(c Cell) bool { return containsCell(subset, c) }
+			eliminated := []Candidate{}
+			for _, val := range allSymbols(union) {
+				eliminated = append(eliminated, l.eliminate(u, val, inSubset)...)
+			}
+			if len(eliminated) > 0 {
+				return Step{Technique: t, Cells: subset, Eliminated: eliminated}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+// hiddenSubset finds n symbols confined to the same n cells of a unit.
+func (l *logical) hiddenSubset(n int, t Technique) (Step, bool) {
+	for u := range units {
+		vals := []int{}
+		for val := 1; val <= 9; val++ {
+			if cnt := len(l.cellsWith(u, val)); cnt > 1 && cnt <= n {
+				vals = append(vals, val)
+			}
+		}
+		for _, combo := range combinations(len(vals), n) {
+			var subsetBits uint
+			subset := []Cell{}
+			for _, vIdx := range combo {
+				subsetBits |= toBit(vals[vIdx])
+				for _, c := range l.cellsWith(u, vals[vIdx]) {
+					if !containsCell(subset, c) {
+						subset = append(subset, c)
+					}
+				}
+			}
+			if len(subset) != n {
+				continue
+			}
+			eliminated := []Candidate{}
+			for _, c := range subset {
+				for _, val := range allSymbols(l.cands[c.Row][c.Col] &^ subsetBits) {
+					eliminated = append(eliminated, Candidate{c, val})
+				}
+			}
+			if len(eliminated) > 0 {
+				return Step{Technique: t, Cells: subset, Eliminated: eliminated}, true
+			}
+		}
+	}
+	return Step{}, false
+}
+
+// fish finds n rows (columns) whose candidates for a symbol lie in only n columns (rows).
+func (l *logical) fish(n int, t Technique) (Step, bool) {
+	for val := 1; val <= 9; val++ {
+		for _, base := range [2]int{0, 9} {
+			cover := 9 - base
+			lines := []int{}
+			for u := base; u < base+9; u++ {
+				if cnt := len(l.cellsWith(u, val)); cnt > 1 && cnt <= n {
+					lines = append(lines, u)
+				}
+			}
+			for _, combo := range combinations(len(lines), n) {
+				cells := []Cell{}
+				var covered uint
+				for _, lIdx := range combo {
+					for _, c := range l.cellsWith(lines[lIdx], val) {
+						cells = append(cells, c)
+						if base == 0 {
+							covered |= 1 << uint(c.Col)
+						} else {
+							covered |= 1 << uint(c.Row)
+						}
+					}
+				}
+				if bits.OnesCount(covered) != n {
+					continue
+				}
+				inFish := func(c Cell) bool { return containsCell(cells, c) }
+				eliminated := []Candidate{}
+				for idx := 0; idx < 9; idx++ {
+					if covered&(1<<uint(idx)) != 0 {
+						eliminated = append(eliminated, l.eliminate(cover+idx, val, inFish)...)
+					}
+				}
+				if len(eliminated) > 0 {
+					return Step{Technique: t, Cells: cells, Eliminated: eliminated}, true
+				}
+			}
+		}
+	}
+	return Step{}, false
+}
+
+// xyWing finds a pivot {x,y} seeing pincers {x,z} and {y,z}.
+// Cells seeing both pincers cannot be z.
+func (l *logical) xyWing() (Step, bool) {
+	return l.wing(2, XYWing)
+}
+
+// xyzWing finds a pivot {x,y,z} seeing pincers {x,z} and {y,z}.
+// Cells seeing the pivot and both pincers cannot be z.
+func (l *logical) xyzWing() (Step, bool) {
+	return l.wing(3, XYZWing)
+}
+
+func (l *logical) wing(pivotSize int, t Technique) (Step, bool) {
+	bivalues := []Cell{}
+	for rowIdx, row := range l.cands {
+		for colIdx, cands := range row {
+			if bits.OnesCount(cands) == 2 {
+				bivalues = append(bivalues, Cell{rowIdx, colIdx})
+			}
+		}
+	}
+	for rowIdx, row := range l.cands {
+		for colIdx, pivotBits := range row {
+			if bits.OnesCount(pivotBits) != pivotSize {
+				continue
+			}
+			pivot := Cell{rowIdx, colIdx}
+			for aIdx, a := range bivalues {
+				for _, b := range bivalues[aIdx+1:] {
+					aBits, bBits := l.cands[a.Row][a.Col], l.cands[b.Row][b.Col]
+					z := aBits & bBits
+					if !sees(pivot, a) || !sees(pivot, b) || aBits == bBits || bits.OnesCount(z) != 1 {
+						continue
+					}
+					if pivotSize == 2 && (aBits|bBits)&^z != pivotBits {
+						continue
+					}
+					if pivotSize == 3 && aBits|bBits != pivotBits {
+						continue
+					}
+					zVal := allSymbols(z)[0]
+					eliminated := []Candidate{}
+					for r := 0; r < 9; r++ {
+						for col := 0; col < 9; col++ {
+							c := Cell{r, col}
+							if c == pivot || !l.has(c, zVal) || !sees(c, a) || !sees(c, b) {
+								continue
+							}
+							if pivotSize == 3 && !sees(c, pivot) {
+								continue
+							}
+							eliminated = append(eliminated, Candidate{c, zVal})
+						}
+					}
+					if len(eliminated) > 0 {
+						return Step{Technique: t, Cells: []Cell{pivot, a, b}, Eliminated: eliminated}, true
+					}
+				}
+			}
+		}
+	}
+	return Step{}, false
+}
+
+func containsCell(cells []Cell, c Cell) bool {
+	for _, other := range cells {
+		if other == c {
+			return true
+		}
+	}
+	return false
+}
+
+// combinations returns all sorted k-element subsets of the indices 0..n-1.
+func combinations(n, k int) [][]int {
+	combos := [][]int{}
+	var rec func(start int, combo []int)
+	rec = func(start int, combo []int) {
+		if len(combo) == k {
+			combos = append(combos, append([]int{}, combo...))
+			return
+		}
+		for idx := start; idx < n; idx++ {
+			rec(idx+1, append(combo, idx))
+		}
+	}
+	rec(0, []int{})
+	return combos
+}
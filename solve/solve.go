@@ -8,8 +8,8 @@ const (
 
 // Backtrack implements a simple backtracking solver. It is not performant but guaranteed to finish.
 func Backtrack(board [9][9]int, maxSolutions int) (bool, [][9][9]int) {
-	solutions := [][9][9]int{}
-	return backtrack(board, maxSolutions, &solutions), solutions
+	s := &search{maxSolutions: maxSolutions, solutions: [][9][9]int{}}
+	return s.backtrack(board), s.solutions
 }
 
 // SolveSingleCandidate tries to solve a board with single candidate strategy.
@@ -23,22 +23,47 @@ func SolveSingleCandidate(board [9][9]int) ([9][9]int, bool) {
 	return board, validate.Solved(board)
 }
 
-func backtrack(board [9][9]int, maxSolutions int, solutions *[][9][9]int) bool {
+// search is the state of a backtracking run.
+type search struct {
+	maxSolutions int
+	solutions    [][9][9]int
+	nodes        int
+	// done stops the search once closed, a nil channel never does.
+	done    <-chan struct{}
+	stopped bool
+}
+
+// backtrack returns true iff the search is finished, either because
+// enough solutions were found or because it was stopped.
+func (s *search) backtrack(board [9][9]int) bool {
+	s.nodes++
+	if s.stop() {
+		return true
+	}
 	rowIdx, colIdx, found := firstEmpty(board)
 	if !found {
-		*solutions = append(*solutions, board)
-		return len(*solutions) >= maxSolutions
+		s.solutions = append(s.solutions, board)
+		return len(s.solutions) >= s.maxSolutions
 	}
 	an := annotateSingleCandidate(board)
 	for _, v := range allSymbols(an.fields[rowIdx][colIdx]) {
 		board[rowIdx][colIdx] = v
-		if backtrack(board, maxSolutions, solutions) {
+		if s.backtrack(board) {
 			return true
 		}
 	}
 	return false
 }
 
+func (s *search) stop() bool {
+	select {
+	case <-s.done:
+		s.stopped = true
+	default:
+	}
+	return s.stopped
+}
+
 func allSymbols(bits uint) []int {
 	syms := []int{}
 	for idx, msk := range [9]uint{2, 4, 8, 16, 32, 64, 128, 256, 512} {
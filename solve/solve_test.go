@@ -1,7 +1,12 @@
 package solve
 
 import (
+	"context"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/sudokoin/sudoku/validate"
 )
 
 var (
@@ -153,3 +158,309 @@ func TestAnnotateSingleCandidate(t *testing.T) {
 		}
 	}
 }
+
+var solveLogicallyTests = []struct {
+	in      [9][9]int
+	hardest Technique
+}{
+	{
+		in: [9][9]int{
+			{9, 0, 0, 1, 6, 0, 2, 7, 5},
+			{1, 0, 5, 0, 0, 0, 0, 0, 0},
+			{0, 7, 0, 0, 0, 9, 0, 0, 0},
+			{2, 0, 3, 4, 0, 0, 8, 0, 0},
+			{0, 5, 0, 0, 0, 0, 0, 0, 7},
+			{0, 0, 0, 0, 0, 0, 0, 0, 1},
+			{0, 6, 0, 0, 0, 0, 0, 4, 8},
+			{0, 0, 2, 9, 0, 0, 0, 0, 0},
+			{8, 0, 0, 0, 3, 0, 0, 6, 0},
+		},
+		hardest: HiddenSingle,
+	},
+	{
+		in: [9][9]int{
+			{0, 5, 0, 1, 0, 0, 0, 7, 0},
+			{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 9, 0, 5, 0, 0, 0, 4},
+			{0, 0, 3, 0, 0, 0, 0, 0, 0},
+			{4, 0, 0, 0, 1, 0, 0, 3, 5},
+			{0, 0, 8, 7, 0, 0, 0, 1, 0},
+			{0, 0, 1, 0, 0, 7, 9, 0, 0},
+			{8, 0, 4, 0, 0, 0, 0, 6, 0},
+			{0, 0, 0, 0, 4, 2, 0, 0, 0},
+		},
+		hardest: PointingPair,
+	},
+	{
+		in: [9][9]int{
+			{0, 7, 6, 0, 0, 0, 1, 0, 5},
+			{1, 0, 0, 0, 0, 0, 0, 8, 9},
+			{0, 5, 0, 0, 0, 8, 0, 0, 0},
+			{0, 1, 0, 0, 0, 0, 7, 0, 8},
+			{3, 0, 0, 0, 0, 9, 0, 0, 0},
+			{0, 0, 0, 0, 0, 2, 0, 4, 0},
+			{0, 0, 0, 8, 0, 0, 0, 0, 0},
+			{0, 4, 0, 0, 7, 1, 0, 0, 0},
+			{7, 0, 2, 0, 0, 0, 6, 0, 0},
+		},
+		hardest: XWing,
+	},
+	{
+		in: [9][9]int{
+			{0, 4, 0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 6, 1, 7, 8, 0, 0, 0},
+			{0, 0, 0, 3, 0, 0, 0, 0, 6},
+			{0, 2, 3, 0, 0, 0, 0, 8, 0},
+			{0, 0, 5, 0, 0, 0, 0, 0, 0},
+			{0, 9, 7, 0, 6, 0, 4, 0, 0},
+			{0, 0, 0, 0, 0, 0, 9, 0, 2},
+			{0, 0, 0, 5, 0, 4, 0, 3, 0},
+			{9, 0, 0, 0, 1, 0, 0, 7, 4},
+		},
+		hardest: HiddenTriple,
+	},
+	{
+		in: [9][9]int{
+			{0, 0, 3, 0, 0, 0, 0, 1, 0},
+			{0, 0, 0, 3, 0, 9, 0, 0, 0},
+			{6, 8, 0, 0, 0, 7, 0, 0, 0},
+			{2, 1, 5, 4, 0, 0, 0, 0, 0},
+			{0, 4, 0, 0, 0, 1, 0, 0, 6},
+			{0, 0, 0, 0, 0, 0, 0, 0, 3},
+			{0, 0, 1, 0, 8, 0, 7, 0, 0},
+			{0, 6, 0, 7, 0, 0, 0, 5, 0},
+			{0, 7, 0, 0, 1, 0, 9, 0, 0},
+		},
+		hardest: XYWing,
+	},
+	{
+		in: [9][9]int{
+			{0, 0, 0, 0, 0, 8, 0, 7, 0},
+			{0, 4, 0, 1, 0, 9, 0, 6, 0},
+			{7, 0, 9, 0, 0, 0, 0, 2, 0},
+			{0, 0, 3, 5, 0, 0, 0, 0, 9},
+			{0, 0, 0, 0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 2, 0, 0, 4, 0, 1},
+			{0, 1, 0, 0, 8, 0, 0, 0, 0},
+			{0, 0, 4, 6, 0, 5, 0, 0, 0},
+			{0, 6, 0, 0, 0, 0, 7, 0, 0},
+		},
+		hardest: XYZWing,
+	},
+}
+
+func TestSolveLogically(t *testing.T) {
+	for _, test := range solveLogicallyTests {
+		solution, steps, success := SolveLogically(test.in)
+		if !success {
+			t.Errorf("expected board to be solved:\n%d\n", solution)
+			continue
+		}
+		hardest := HiddenSingle
+		for _, step := range steps {
+			if step.Technique > hardest {
+				hardest = step.Technique
+			}
+			if step.Placed.Val != 0 && solution[step.Placed.Row][step.Placed.Col] != step.Placed.Val {
+				t.Errorf("unexpected placement by %v: %+v", step.Technique, step.Placed)
+			}
+			for _, e := range step.Eliminated {
+				if solution[e.Row][e.Col] == e.Val {
+					t.Errorf("unexpected elimination by %v: %+v", step.Technique, e)
+				}
+			}
+		}
+		if test.hardest != hardest {
+			t.Errorf("unexpected hardest technique:\n%v\n%v\n", test.hardest, hardest)
+		}
+	}
+}
+
+var hard = [9][9]int{
+	{1, 0, 0, 0, 0, 7, 0, 9, 0},
+	{0, 3, 0, 0, 2, 0, 0, 0, 8},
+	{0, 0, 9, 6, 0, 0, 5, 0, 0},
+	{0, 0, 5, 3, 0, 0, 9, 0, 0},
+	{0, 1, 0, 0, 8, 0, 0, 0, 2},
+	{6, 0, 0, 0, 0, 4, 0, 0, 0},
+	{3, 0, 0, 0, 0, 0, 0, 1, 0},
+	{0, 4, 0, 0, 0, 0, 0, 0, 7},
+	{0, 0, 7, 0, 0, 0, 3, 0, 0},
+}
+
+var solverTests = []struct {
+	id           string
+	in           [9][9]int
+	maxSolutions int
+	solutions    int
+}{
+	{id: "working", in: working, maxSolutions: 2, solutions: 1},
+	{id: "solvable", in: solvable, maxSolutions: 2, solutions: 1},
+	{id: "unsolvable", in: unsolvable, maxSolutions: 5, solutions: 5},
+	{id: "hard", in: hard, maxSolutions: 2, solutions: 1},
+	{id: "empty", in: emptyBoard, maxSolutions: 3, solutions: 3},
+	{id: "repeated symbol", in: [9][9]int{{1, 1}}, maxSolutions: 2, solutions: 0},
+	{id: "invalid symbol", in: [9][9]int{{10}}, maxSolutions: 2, solutions: 0},
+}
+
+func TestDancingLinks(t *testing.T) {
+	testSolver(t, DancingLinks)
+}
+
+func TestBitboard(t *testing.T) {
+	testSolver(t, Bitboard)
+}
+
+func testSolver(t *testing.T, solver func([9][9]int, int) (bool, [][9][9]int)) {
+	for _, test := range solverTests {
+		done, solutions := solver(test.in, test.maxSolutions)
+		if test.solutions != len(solutions) || done != (test.solutions >= test.maxSolutions) {
+			t.Errorf("unexpected result for %s:\n%d\n%v %d\n", test.id, test.solutions, done, len(solutions))
+		}
+		for _, solution := range solutions {
+			if !validate.Solved(solution) || !keepsClues(test.in, solution) {
+				t.Errorf("unexpected solution for %s:\n%d\n", test.id, solution)
+			}
+		}
+	}
+}
+
+func keepsClues(board [9][9]int, solution [9][9]int) bool {
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			if val != 0 && solution[rowIdx][colIdx] != val {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func BenchmarkBacktrack(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Backtrack(hard, 2)
+	}
+}
+
+func BenchmarkDancingLinks(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		DancingLinks(hard, 2)
+	}
+}
+
+func BenchmarkBitboard(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Bitboard(hard, 2)
+	}
+}
+
+func TestBacktrackContext(t *testing.T) {
+	res, err := BacktrackContext(context.Background(), hard, 2)
+	if err != nil || res.MaxReached || len(res.Solutions) != 1 || res.Nodes == 0 {
+		t.Errorf("unexpected result: %v %v %d %d", err, res.MaxReached, len(res.Solutions), res.Nodes)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	res, err = BacktrackContext(ctx, emptyBoard, 1000000000)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected deadline to be exceeded: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected prompt return after deadline, took %v", elapsed)
+	}
+	if res.MaxReached || len(res.Solutions) == 0 || res.Nodes == 0 {
+		t.Errorf("expected partial result: %v %d %d", res.MaxReached, len(res.Solutions), res.Nodes)
+	}
+}
+
+func TestSolveSingleCandidateContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	solution, success, err := SolveSingleCandidateContext(ctx, solvable)
+	if err != nil || !success || solution != working {
+		t.Errorf("unexpected result: %v %v\n%d\n", err, success, solution)
+	}
+
+	cancel()
+	solution, success, err = SolveSingleCandidateContext(ctx, solvable)
+	if err != context.Canceled || success || solution != solvable {
+		t.Errorf("unexpected result after cancel: %v %v\n%d\n", err, success, solution)
+	}
+}
+
+func TestCountSolutions(t *testing.T) {
+	for _, test := range solverTests {
+		count := CountSolutions(test.in, test.maxSolutions)
+		if test.solutions != count {
+			t.Errorf("unexpected count for %s:\n%d\n%d\n", test.id, test.solutions, count)
+		}
+		if unique := HasUniqueSolution(test.in); unique != (test.solutions == 1) {
+			t.Errorf("unexpected uniqueness for %s: %v", test.id, unique)
+		}
+	}
+}
+
+func BenchmarkCountSolutions(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		CountSolutions(hard, 2)
+	}
+}
+
+func TestCandidates(t *testing.T) {
+	cands := NewCandidates(unsolvable)
+	for rowIdx, row := range unsolvable {
+		for colIdx, val := range row {
+			if val != 0 && cands.Count(rowIdx, colIdx) != 0 {
+				t.Errorf("expected no candidates for filled field %d,%d", rowIdx, colIdx)
+			}
+		}
+	}
+	if vals := cands.Get(0, 4); !reflect.DeepEqual(vals, []int{2, 5, 6, 8}) {
+		t.Errorf("unexpected candidates: %v", vals)
+	}
+
+	cands.Eliminate(0, 4, 5)
+	if cands.Has(0, 4, 5) || !cands.Has(0, 4, 6) || cands.Count(0, 4) != 3 {
+		t.Errorf("unexpected candidates after elimination: %v", cands.Get(0, 4))
+	}
+
+	cands.Place(0, 4, 6)
+	if cands.Count(0, 4) != 0 || cands.Has(6, 4, 6) || cands.Has(1, 4, 6) || !cands.Has(1, 4, 8) {
+		t.Errorf("unexpected candidates after placement: %v %v", cands.Get(6, 4), cands.Get(1, 4))
+	}
+}
+
+func TestHint(t *testing.T) {
+	step, found := Hint(solvable)
+	if !found || step.Technique != HiddenSingle || step.Placed != (Candidate{Cell{0, 1}, 8}) {
+		t.Errorf("unexpected hint: %v %+v", found, step)
+	}
+	if step, found := Hint(working); found {
+		t.Errorf("expected no hint for solved board: %+v", step)
+	}
+
+	marks := NewCandidates(solvable)
+	marks.Eliminate(0, 1, 8)
+	step, found = HintWithCandidates(solvable, marks)
+	if !found || step.Placed != (Candidate{Cell{6, 1}, 7}) {
+		t.Errorf("unexpected hint with candidates: %v %+v", found, step)
+	}
+
+	_, solutions := Bitboard(hard, 1)
+	partial := Candidates{}
+	partial[0][1] = NewCandidates(hard)[0][1]
+	step, found = HintWithCandidates(hard, partial)
+	expected, _ := Hint(hard)
+	if !found || !reflect.DeepEqual(step, expected) {
+		t.Errorf("expected partial marks to give the hint without marks, got %v %+v", found, step)
+	}
+	if found && step.Placed.Val != 0 && solutions[0][step.Placed.Row][step.Placed.Col] != step.Placed.Val {
+		t.Errorf("hint contradicts solution: %+v", step)
+	}
+
+	partial[0][1] = toBit(9) // 9 is already in row 0
+	if step, found := HintWithCandidates(hard, partial); found {
+		t.Errorf("expected no hint for contradictory marks: %+v", step)
+	}
+}
@@ -0,0 +1,111 @@
+// Package sudoku includes helpers to generate, solve, rate, validate, transform and convert 9x9 sudokus.
+// The sub packages take and return boards as [9][9]int. Since the Board type defined here
+// has that underlying type, a Board can be passed to them and their results can be assigned
+// to a Board without conversion. Only slices of solutions need Boards.
+package sudoku
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Board is a 9x9 sudoku. Empty fields are 0, filled fields hold a symbol of 1-9.
+// Since its underlying type is [9][9]int, a Board can be passed to all functions
+// taking a [9][9]int board.
+type Board [9][9]int
+
+// Boards converts a slice of boards, e.g. the solutions returned by the solvers.
+func Boards(boards [][9][9]int) []Board {
+	converted := make([]Board, len(boards))
+	for idx, board := range boards {
+		converted[idx] = board
+	}
+	return converted
+}
+
+// Get returns the value of a field.
+func (b Board) Get(row, col int) int {
+	return b[row][col]
+}
+
+// Set changes the value of a field. 0 empties it.
+// An error is returned iff the position or value is out of range.
+func (b *Board) Set(row, col, val int) error {
+	if row < 0 || row > 8 || col < 0 || col > 8 {
+		return errors.Errorf("position %d,%d out of range", row, col)
+	}
+	if val < 0 || val > 9 {
+		return errors.Errorf("value %d out of range", val)
+	}
+	b[row][col] = val
+	return nil
+}
+
+// Row returns the values of a row.
+func (b Board) Row(idx int) [9]int {
+	return b[idx]
+}
+
+// Col returns the values of a column.
+func (b Board) Col(idx int) [9]int {
+	col := [9]int{}
+	for rowIdx, row := range b {
+		col[rowIdx] = row[idx]
+	}
+	return col
+}
+
+// Block returns the values of a 3x3 block. Blocks are numbered
+// from left to right and top to bottom.
+func (b Board) Block(idx int) [9]int {
+	block := [9]int{}
+	for fIdx := range block {
+		block[fIdx] = b[idx/3*3+fIdx/3][idx%3*3+fIdx%3]
+	}
+	return block
+}
+
+// Clues returns the number of filled fields.
+func (b Board) Clues() int {
+	clues := 0
+	for _, row := range b {
+		for _, val := range row {
+			if val != 0 {
+				clues++
+			}
+		}
+	}
+	return clues
+}
+
+// Clone returns a copy of the board.
+func (b Board) Clone() Board {
+	return b
+}
+
+// String returns the board as grid of 9 lines with '.' for empty fields.
+func (b Board) String() string {
+	var sb strings.Builder
+	for rowIdx, row := range b {
+		if rowIdx == 3 || rowIdx == 6 {
+			sb.WriteString("------+-------+------\n")
+		}
+		for colIdx, val := range row {
+			if colIdx == 3 || colIdx == 6 {
+				sb.WriteString(" |")
+			}
+			if colIdx > 0 {
+				sb.WriteString(" ")
+			}
+			if val == 0 {
+				sb.WriteString(".")
+			} else {
+				sb.WriteString(strconv.Itoa(val))
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
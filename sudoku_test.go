@@ -0,0 +1,78 @@
+package sudoku_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sudokoin/sudoku"
+)
+
+var working = sudoku.Board{
+	{9, 8, 7, 6, 5, 4, 3, 2, 1},
+	{6, 5, 4, 3, 2, 1, 9, 8, 7},
+	{3, 2, 1, 9, 8, 7, 6, 5, 4},
+	{8, 9, 6, 7, 4, 5, 2, 1, 3},
+	{7, 4, 5, 2, 1, 3, 8, 9, 6},
+	{2, 1, 3, 8, 9, 6, 7, 4, 5},
+	{5, 7, 9, 4, 6, 8, 1, 3, 2},
+	{4, 6, 8, 1, 3, 2, 5, 7, 9},
+	{1, 3, 2, 5, 7, 9, 4, 6, 8},
+}
+
+func ExampleBoard_String() {
+	board := working.Clone()
+	for col := 0; col < 9; col++ {
+		board.Set(4, col, 0)
+	}
+	fmt.Print(board)
+	// Output:
+	// 9 8 7 | 6 5 4 | 3 2 1
+	// 6 5 4 | 3 2 1 | 9 8 7
+	// 3 2 1 | 9 8 7 | 6 5 4
+	// ------+-------+------
+	// 8 9 6 | 7 4 5 | 2 1 3
+	// . . . | . . . | . . .
+	// 2 1 3 | 8 9 6 | 7 4 5
+	// ------+-------+------
+	// 5 7 9 | 4 6 8 | 1 3 2
+	// 4 6 8 | 1 3 2 | 5 7 9
+	// 1 3 2 | 5 7 9 | 4 6 8
+}
+
+func TestBoardGroups(t *testing.T) {
+	if row := working.Row(3); row != [9]int{8, 9, 6, 7, 4, 5, 2, 1, 3} {
+		t.Errorf("unexpected row: %v", row)
+	}
+	if col := working.Col(1); col != [9]int{8, 5, 2, 9, 4, 1, 7, 6, 3} {
+		t.Errorf("unexpected col: %v", col)
+	}
+	if block := working.Block(5); block != [9]int{2, 1, 3, 8, 9, 6, 7, 4, 5} {
+		t.Errorf("unexpected block: %v", block)
+	}
+}
+
+func TestBoardSet(t *testing.T) {
+	board := working.Clone()
+	if err := board.Set(0, 0, 0); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if board.Get(0, 0) != 0 || working.Get(0, 0) != 9 {
+		t.Errorf("expected only clone to change:\n%v\n%v", board, working)
+	}
+	if board.Clues() != 80 {
+		t.Errorf("unexpected clue count: %d", board.Clues())
+	}
+	for _, set := range [][3]int{{0, 0, 10}, {0, 0, -1}, {9, 0, 1}, {0, -1, 1}} {
+		if err := board.Set(set[0], set[1], set[2]); err == nil {
+			t.Errorf("expected error for %v", set)
+		}
+	}
+}
+
+func TestBoards(t *testing.T) {
+	solutions := [][9][9]int{working, {}}
+	boards := sudoku.Boards(solutions)
+	if len(boards) != 2 || boards[0] != working || boards[1].Clues() != 0 {
+		t.Errorf("unexpected boards: %v", boards)
+	}
+}
@@ -0,0 +1,202 @@
+// Package transform contains validity preserving transformations of 9x9 sudokus.
+// A Transformation applied to a puzzle and its solution yields a puzzle with the
+// transformed solution, which is unique iff the original one was.
+package transform
+
+import (
+	"math/rand"
+
+	"github.com/pkg/errors"
+)
+
+// Transformation changes a board without breaking any rule.
+type Transformation func(board [9][9]int) [9][9]int
+
+// Chain returns a Transformation applying all provided ones in order.
+func Chain(ts ...Transformation) Transformation {
+	return func(board [9][9]int) [9][9]int {
+		for _, t := range ts {
+			board = t(board)
+		}
+		return board
+	}
+}
+
+// Relabel replaces each symbol s by perm[s-1]. Empty fields stay empty.
+// An error is returned iff perm is not a permutation of 1-9.
+func Relabel(perm [9]int) (Transformation, error) {
+	seen := [10]bool{}
+	for _, val := range perm {
+		if val < 1 || val > 9 || seen[val] {
+			return nil, errors.Errorf("%v is no permutation of 1-9", perm)
+		}
+		seen[val] = true
+	}
+	return func(board [9][9]int) [9][9]int {
+		for rowIdx, row := range board {
+			for colIdx, val := range row {
+				if val > 0 && val <= 9 {
+					board[rowIdx][colIdx] = perm[val-1]
+				}
+			}
+		}
+		return board
+	}, nil
+}
+
+// SwapRows swaps two rows of the same band.
+// An error is returned iff the rows are out of range or in different bands.
+func SwapRows(a, b int) (Transformation, error) {
+	if err := checkSameThird(a, b); err != nil {
+		return nil, errors.Wrap(err, "cannot swap rows")
+	}
+	return func(board [9][9]int) [9][9]int {
+		board[a], board[b] = board[b], board[a]
+		return board
+	}, nil
+}
+
+// SwapCols swaps two columns of the same stack.
+// An error is returned iff the columns are out of range or in different stacks.
+func SwapCols(a, b int) (Transformation, error) {
+	if err := checkSameThird(a, b); err != nil {
+		return nil, errors.Wrap(err, "cannot swap columns")
+	}
+	return func(board [9][9]int) [9][9]int {
+		for rowIdx := range board {
+			board[rowIdx][a], board[rowIdx][b] = board[rowIdx][b], board[rowIdx][a]
+		}
+		return board
+	}, nil
+}
+
+// PermuteBands moves band perm[i] to position i.
+// An error is returned iff perm is not a permutation of 0-2.
+func PermuteBands(perm [3]int) (Transformation, error) {
+	if err := checkThirds(perm); err != nil {
+		return nil, err
+	}
+	order := [9]int{}
+	for rowIdx := range order {
+		order[rowIdx] = perm[rowIdx/3]*3 + rowIdx%3
+	}
+	return reorderRows(order), nil
+}
+
+// reorderRows moves row order[i] to position i.
+func reorderRows(order [9]int) Transformation {
+	return func(board [9][9]int) [9][9]int {
+		reordered := [9][9]int{}
+		for rowIdx, from := range order {
+			reordered[rowIdx] = board[from]
+		}
+		return reordered
+	}
+}
+
+// PermuteStacks moves stack perm[i] to position i.
+// An error is returned iff perm is not a permutation of 0-2.
+func PermuteStacks(perm [3]int) (Transformation, error) {
+	permuteBands, err := PermuteBands(perm)
+	if err != nil {
+		return nil, err
+	}
+	return Chain(Transpose, permuteBands, Transpose), nil
+}
+
+// Transpose mirrors a board along its main diagonal.
+func Transpose(board [9][9]int) [9][9]int {
+	transposed := [9][9]int{}
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			transposed[colIdx][rowIdx] = val
+		}
+	}
+	return transposed
+}
+
+// MirrorHorizontal mirrors a board along its horizontal axis, swapping top and bottom.
+func MirrorHorizontal(board [9][9]int) [9][9]int {
+	mirrored := [9][9]int{}
+	for rowIdx, row := range board {
+		mirrored[8-rowIdx] = row
+	}
+	return mirrored
+}
+
+// MirrorVertical mirrors a board along its vertical axis, swapping left and right.
+func MirrorVertical(board [9][9]int) [9][9]int {
+	mirrored := [9][9]int{}
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			mirrored[rowIdx][8-colIdx] = val
+		}
+	}
+	return mirrored
+}
+
+// Rotate turns a board clockwise by the given number of quarter turns.
+// Negative numbers turn counterclockwise.
+func Rotate(quarterTurns int) Transformation {
+	return func(board [9][9]int) [9][9]int {
+		for turn := 0; turn < (quarterTurns%4+4)%4; turn++ {
+			board = MirrorVertical(Transpose(board))
+		}
+		return board
+	}
+}
+
+// Random returns a random combination of all transformations.
+// Applying it to one solved board yields one of more than 3 billion equivalent boards.
+func Random(r *rand.Rand) Transformation {
+	perm := [9]int{}
+	for idx, val := range r.Perm(9) {
+		perm[idx] = val + 1
+	}
+	relabel, _ := Relabel(perm)
+	ts := []Transformation{relabel}
+	rows, cols := [9]int{}, [9]int{}
+	for third := 0; third < 3; third++ {
+		for idx, val := range randomThird(r) {
+			rows[third*3+idx] = third*3 + val
+		}
+		for idx, val := range randomThird(r) {
+			cols[third*3+idx] = third*3 + val
+		}
+	}
+	ts = append(ts, reorderRows(rows), Transpose, reorderRows(cols), Transpose)
+	permuteBands, _ := PermuteBands(randomThird(r))
+	permuteStacks, _ := PermuteStacks(randomThird(r))
+	ts = append(ts, permuteBands, permuteStacks)
+	if r.Intn(2) == 1 {
+		ts = append(ts, Transpose)
+	}
+	return Chain(ts...)
+}
+
+func randomThird(r *rand.Rand) [3]int {
+	perm := [3]int{}
+	copy(perm[:], r.Perm(3))
+	return perm
+}
+
+func checkSameThird(a, b int) error {
+	if a < 0 || a > 8 || b < 0 || b > 8 {
+		return errors.Errorf("%d or %d out of range", a, b)
+	}
+	if a/3 != b/3 {
+		return errors.Errorf("%d and %d not in the same third", a, b)
+	}
+	return nil
+}
+
+func checkThirds(perm [3]int) error {
+	seen := [3]bool{}
+	for _, val := range perm {
+		if val < 0 || val > 2 || seen[val] {
+			return errors.Errorf("%v is no permutation of 0-2", perm)
+		}
+		seen[val] = true
+	}
+	return nil
+}
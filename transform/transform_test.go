@@ -0,0 +1,145 @@
+package transform_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/sudokoin/sudoku/convert"
+	"github.com/sudokoin/sudoku/solve"
+	"github.com/sudokoin/sudoku/transform"
+	"github.com/sudokoin/sudoku/validate"
+)
+
+var puzzle = [9][9]int{
+	{1, 0, 0, 0, 0, 7, 0, 9, 0},
+	{0, 3, 0, 0, 2, 0, 0, 0, 8},
+	{0, 0, 9, 6, 0, 0, 5, 0, 0},
+	{0, 0, 5, 3, 0, 0, 9, 0, 0},
+	{0, 1, 0, 0, 8, 0, 0, 0, 2},
+	{6, 0, 0, 0, 0, 4, 0, 0, 0},
+	{3, 0, 0, 0, 0, 0, 0, 1, 0},
+	{0, 4, 0, 0, 0, 0, 0, 0, 7},
+	{0, 0, 7, 0, 0, 0, 3, 0, 0},
+}
+
+func mustTransform(t transform.Transformation, err error) transform.Transformation {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+var transformTests = []struct {
+	name string
+	t    transform.Transformation
+}{
+	{"relabel", mustTransform(transform.Relabel([9]int{9, 8, 7, 6, 5, 4, 3, 2, 1}))},
+	{"swap rows", mustTransform(transform.SwapRows(3, 5))},
+	{"swap cols", mustTransform(transform.SwapCols(6, 8))},
+	{"permute bands", mustTransform(transform.PermuteBands([3]int{2, 0, 1}))},
+	{"permute stacks", mustTransform(transform.PermuteStacks([3]int{1, 2, 0}))},
+	{"transpose", transform.Transpose},
+	{"rotate", transform.Rotate(1)},
+	{"rotate back", transform.Rotate(-1)},
+	{"mirror horizontal", transform.MirrorHorizontal},
+	{"mirror vertical", transform.MirrorVertical},
+	{"random", transform.Random(rand.New(rand.NewSource(1)))},
+	{"chain", transform.Chain(transform.Transpose, transform.Rotate(2), transform.MirrorVertical)},
+}
+
+func TestTransformations(t *testing.T) {
+	_, solutions := solve.Bitboard(puzzle, 1)
+	solution := solutions[0]
+	for _, test := range transformTests {
+		p, s := test.t(puzzle), test.t(solution)
+		if p == puzzle || s == solution {
+			t.Errorf("%s: board unchanged", test.name)
+		}
+		if !validate.Solved(s) {
+			t.Errorf("%s: solution not solved anymore", test.name)
+		}
+		if !solve.HasUniqueSolution(p) {
+			t.Errorf("%s: puzzle not unique anymore", test.name)
+			continue
+		}
+		_, transformed := solve.Bitboard(p, 1)
+		if transformed[0] != s {
+			t.Errorf("%s: solution of transformed puzzle differs from transformed solution", test.name)
+		}
+	}
+}
+
+func TestRandom(t *testing.T) {
+	variant := transform.Random(rand.New(rand.NewSource(2)))(puzzle)
+	if !convert.Equivalent(puzzle, variant) {
+		t.Error("random variant not equivalent")
+	}
+}
+
+func TestRandomOrders(t *testing.T) {
+	// row i has i+1 clues and column j has j+1 clues, so the counts reveal where
+	// each row and column went; transposing swaps both, which keeps the orders reachable
+	triangle := [9][9]int{}
+	for rowIdx := range triangle {
+		for colIdx := 8 - rowIdx; colIdx < 9; colIdx++ {
+			triangle[rowIdx][colIdx] = 1
+		}
+	}
+	rowOrders, colOrders := map[[3]int]bool{}, map[[3]int]bool{}
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 500; n++ {
+		variant := transform.Random(r)(triangle)
+		rowCounts, colCounts := [9]int{}, [9]int{}
+		for rowIdx, row := range variant {
+			for colIdx, val := range row {
+				if val != 0 {
+					rowCounts[rowIdx]++
+					colCounts[colIdx]++
+				}
+			}
+		}
+		rowOrders[firstThird(rowCounts)] = true
+		colOrders[firstThird(colCounts)] = true
+	}
+	if len(rowOrders) != 6 || len(colOrders) != 6 {
+		t.Errorf("expected all 6 orders within a band and stack, got %v and %v", rowOrders, colOrders)
+	}
+}
+
+// firstThird returns the order of the lines with 1-3 clues.
+func firstThird(counts [9]int) [3]int {
+	for third := 0; third < 3; third++ {
+		order := [3]int{counts[third*3], counts[third*3+1], counts[third*3+2]}
+		if order[0]+order[1]+order[2] == 6 {
+			return order
+		}
+	}
+	return [3]int{}
+}
+
+func TestRotate(t *testing.T) {
+	if transform.Rotate(4)(puzzle) != puzzle || transform.Rotate(3)(puzzle) != transform.Rotate(-1)(puzzle) {
+		t.Error("rotations are not cyclic")
+	}
+	if transform.Rotate(1)(puzzle)[0][8] != puzzle[0][0] {
+		t.Error("rotation is not clockwise")
+	}
+}
+
+func TestInvalidArguments(t *testing.T) {
+	if _, err := transform.Relabel([9]int{1, 1, 2, 3, 4, 5, 6, 7, 8}); err == nil {
+		t.Error("expected error for repeated label")
+	}
+	if _, err := transform.SwapRows(2, 3); err == nil {
+		t.Error("expected error for rows of different bands")
+	}
+	if _, err := transform.SwapCols(8, 9); err == nil {
+		t.Error("expected error for column out of range")
+	}
+	if _, err := transform.PermuteBands([3]int{0, 1, 1}); err == nil {
+		t.Error("expected error for invalid band order")
+	}
+	if _, err := transform.PermuteStacks([3]int{0, 1, 3}); err == nil {
+		t.Error("expected error for invalid stack order")
+	}
+}
@@ -0,0 +1,169 @@
+package validate
+
+// Cell is the position of a field on the board.
+type Cell struct {
+	Row, Col int
+}
+
+// Report lists where a board breaks the rules.
+// Blocks are numbered from left to right and top to bottom.
+type Report struct {
+	// Rows, Cols and Blocks hold the indices of groups containing a symbol more than once.
+	Rows   []int
+	Cols   []int
+	Blocks []int
+	// Conflicts are the cells holding a symbol that is repeated in one of their groups.
+	Conflicts []Cell
+	// OutOfRange are the cells holding values other than 0-9.
+	OutOfRange []Cell
+	// Empty are the cells holding 0.
+	Empty []Cell
+	// Dead are the empty cells that cannot be filled since their
+	// row, column and block already contain all symbols.
+	Dead []Cell
+	// Altered are the cells whose clue was changed, see Submission.
+	Altered []Cell
+}
+
+// Consistent returns true iff a partially filled board breaks no rule
+// and none of its empty cells is dead.
+func Consistent(board [9][9]int) bool {
+	return Inspect(board).Consistent()
+}
+
+// Submission checks a board submitted as solution of puzzle.
+// Besides the problems reported by Inspect it reports the clues of puzzle
+// that were changed or removed on board.
+func Submission(puzzle [9][9]int, board [9][9]int) Report {
+	r := Inspect(board)
+	for rowIdx, row := range puzzle {
+		for colIdx, val := range row {
+			if val != 0 && board[rowIdx][colIdx] != val {
+				r.Altered = append(r.Altered, Cell{rowIdx, colIdx})
+			}
+		}
+	}
+	return r
+}
+
+// Inspect checks a board and reports where it breaks the rules.
+// Empty cells are reported but not considered a violation by Valid.
+func Inspect(board [9][9]int) Report {
+	r := Report{}
+	taken := takenSymbols(board)
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			if val < 0 || val > 9 {
+				r.OutOfRange = append(r.OutOfRange, Cell{rowIdx, colIdx})
+			} else if val == 0 {
+				r.Empty = append(r.Empty, Cell{rowIdx, colIdx})
+				if taken[rowIdx]|taken[9+colIdx]|taken[18+rowIdx/3*3+colIdx/3] == allSymbols {
+					r.Dead = append(r.Dead, Cell{rowIdx, colIdx})
+				}
+			}
+		}
+	}
+	conflicting := [9][9]bool{}
+	for idx := 0; idx < 9; idx++ {
+		if markRepeated(board, rowCells(idx), &conflicting) {
+			r.Rows = append(r.Rows, idx)
+		}
+		if markRepeated(board, colCells(idx), &conflicting) {
+			r.Cols = append(r.Cols, idx)
+		}
+		if markRepeated(board, blockCells(idx), &conflicting) {
+			r.Blocks = append(r.Blocks, idx)
+		}
+	}
+	for rowIdx, row := range conflicting {
+		for colIdx, c := range row {
+			if c {
+				r.Conflicts = append(r.Conflicts, Cell{rowIdx, colIdx})
+			}
+		}
+	}
+	return r
+}
+
+// Valid returns true iff no symbol is repeated, all values are in the range of 0-9
+// and no clue was altered.
+func (r Report) Valid() bool {
+	return len(r.Conflicts) == 0 && len(r.OutOfRange) == 0 && len(r.Altered) == 0
+}
+
+// Consistent returns true iff the board is valid and has no dead cells.
+// Inconsistent boards cannot be solved.
+func (r Report) Consistent() bool {
+	return r.Valid() && len(r.Dead) == 0
+}
+
+// Solved returns true iff the board is valid and complete.
+func (r Report) Solved() bool {
+	return r.Valid() && len(r.Empty) == 0
+}
+
+// allSymbols has bits 1-9 set.
+const allSymbols uint = 1022
+
+// takenSymbols returns the symbols of all rows (0-8), columns (9-17) and blocks (18-26)
+// with bit n set iff symbol n occurs in the group.
+func takenSymbols(board [9][9]int) [27]uint {
+	taken := [27]uint{}
+	for rowIdx, row := range board {
+		for colIdx, val := range row {
+			if val > 0 && val <= 9 {
+				bit := uint(1) << uint(val)
+				taken[rowIdx] |= bit
+				taken[9+colIdx] |= bit
+				taken[18+rowIdx/3*3+colIdx/3] |= bit
+			}
+		}
+	}
+	return taken
+}
+
+// markRepeated marks all cells of a group holding a symbol that occurs more than once.
+// It returns true iff any symbol is repeated.
+func markRepeated(board [9][9]int, group [9]Cell, conflicting *[9][9]bool) bool {
+	seen := [10][]Cell{}
+	for _, c := range group {
+		if val := board[c.Row][c.Col]; val > 0 && val <= 9 {
+			seen[val] = append(seen[val], c)
+		}
+	}
+	repeated := false
+	for _, cells := range seen {
+		if len(cells) < 2 {
+			continue
+		}
+		repeated = true
+		for _, c := range cells {
+			conflicting[c.Row][c.Col] = true
+		}
+	}
+	return repeated
+}
+
+func rowCells(idx int) [9]Cell {
+	cells := [9]Cell{}
+	for colIdx := range cells {
+		cells[colIdx] = Cell{idx, colIdx}
+	}
+	return cells
+}
+
+func colCells(idx int) [9]Cell {
+	cells := [9]Cell{}
+	for rowIdx := range cells {
+		cells[rowIdx] = Cell{rowIdx, idx}
+	}
+	return cells
+}
+
+func blockCells(idx int) [9]Cell {
+	cells := [9]Cell{}
+	for cIdx := range cells {
+		cells[cIdx] = Cell{idx/3*3 + cIdx/3, idx%3*3 + cIdx%3}
+	}
+	return cells
+}
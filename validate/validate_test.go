@@ -0,0 +1,166 @@
+package validate_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sudokoin/sudoku/validate"
+)
+
+var (
+	working = [9][9]int{
+		{9, 8, 7, 6, 5, 4, 3, 2, 1},
+		{6, 5, 4, 3, 2, 1, 9, 8, 7},
+		{3, 2, 1, 9, 8, 7, 6, 5, 4},
+		{8, 9, 6, 7, 4, 5, 2, 1, 3},
+		{7, 4, 5, 2, 1, 3, 8, 9, 6},
+		{2, 1, 3, 8, 9, 6, 7, 4, 5},
+		{5, 7, 9, 4, 6, 8, 1, 3, 2},
+		{4, 6, 8, 1, 3, 2, 5, 7, 9},
+		{1, 3, 2, 5, 7, 9, 4, 6, 8},
+	}
+	withEmptyAndOutOfRange = [9][9]int{
+		{0, 8, 7, 6, 5, 4, 3, 2, 1},
+		{6, 5, 4, 3, 2, 1, 9, 8, 7},
+		{3, 2, 1, 9, 8, 7, 6, 5, 4},
+		{8, 9, 6, 7, 4, 5, 2, 1, 3},
+		{7, 4, 5, 2, 10, 3, 8, 9, 6},
+		{2, 1, 3, 8, 9, 6, 7, 4, 5},
+		{5, 7, 9, 4, 6, 8, 1, 3, 2},
+		{4, 6, 8, 1, 3, 2, 5, 7, 9},
+		{1, 3, 2, 5, 7, 9, 4, 6, -1},
+	}
+	puzzle = [9][9]int{
+		{9, 0, 0, 6, 5, 4, 3, 2, 1},
+		{6, 5, 4, 3, 2, 1, 9, 8, 7},
+		{3, 2, 1, 9, 8, 7, 6, 5, 4},
+		{8, 9, 6, 7, 4, 5, 2, 1, 3},
+		{7, 4, 5, 2, 1, 3, 8, 9, 6},
+		{2, 1, 3, 8, 9, 6, 7, 4, 5},
+		{5, 0, 9, 4, 6, 8, 1, 3, 2},
+		{4, 6, 8, 1, 3, 2, 5, 7, 9},
+		{1, 3, 2, 5, 7, 9, 4, 6, 8},
+	}
+	withDead = [9][9]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{9, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0, 0, 0, 0},
+	}
+	withRepeated = [9][9]int{
+		{9, 9, 7, 6, 5, 4, 3, 2, 1},
+		{6, 5, 4, 3, 2, 1, 9, 8, 7},
+		{3, 2, 1, 9, 8, 7, 6, 5, 4},
+		{8, 9, 6, 7, 4, 5, 2, 1, 3},
+		{7, 4, 5, 2, 1, 3, 8, 9, 6},
+		{2, 1, 3, 8, 9, 6, 7, 4, 5},
+		{5, 7, 9, 4, 6, 8, 1, 3, 2},
+		{4, 6, 8, 1, 3, 2, 5, 7, 9},
+		{1, 3, 2, 5, 7, 9, 4, 6, 8},
+	}
+)
+
+var inspectTests = []struct {
+	id     string
+	in     [9][9]int
+	out    validate.Report
+	valid  bool
+	solved bool
+}{
+	{
+		id:     "working",
+		in:     working,
+		out:    validate.Report{},
+		valid:  true,
+		solved: true,
+	}, {
+		id: "empty and out of range",
+		in: withEmptyAndOutOfRange,
+		out: validate.Report{
+			OutOfRange: []validate.Cell{{4, 4}, {8, 8}},
+			Empty:      []validate.Cell{{0, 0}},
+		},
+	}, {
+		id: "repeated symbol",
+		in: withRepeated,
+		out: validate.Report{
+			Rows:      []int{0},
+			Cols:      []int{1},
+			Blocks:    []int{0},
+			Conflicts: []validate.Cell{{0, 0}, {0, 1}, {3, 1}},
+		},
+	},
+}
+
+func TestInspect(t *testing.T) {
+	for _, test := range inspectTests {
+		out := validate.Inspect(test.in)
+		if !reflect.DeepEqual(test.out, out) {
+			t.Errorf("unexpected report for %s:\n%+v\n%+v\n", test.id, test.out, out)
+		}
+		if test.valid != out.Valid() {
+			t.Errorf("unexpected valid bool for %s: %v", test.id, out.Valid())
+		}
+		if test.solved != out.Solved() {
+			t.Errorf("unexpected solved bool for %s: %v", test.id, out.Solved())
+		}
+	}
+}
+
+var consistentTests = []struct {
+	id   string
+	in   [9][9]int
+	dead []validate.Cell
+	out  bool
+}{
+	{id: "working", in: working, out: true},
+	{id: "empty", in: [9][9]int{}, out: true},
+	{id: "dead cell", in: withDead, dead: []validate.Cell{{0, 0}}},
+	{id: "repeated symbol", in: withRepeated},
+	{id: "out of range", in: withEmptyAndOutOfRange},
+}
+
+func TestConsistent(t *testing.T) {
+	for _, test := range consistentTests {
+		if out := validate.Consistent(test.in); test.out != out {
+			t.Errorf("unexpected consistent bool for %s: %v", test.id, out)
+		}
+		if dead := validate.Inspect(test.in).Dead; !reflect.DeepEqual(test.dead, dead) {
+			t.Errorf("unexpected dead cells for %s:\n%v\n%v\n", test.id, test.dead, dead)
+		}
+	}
+}
+
+var submissionTests = []struct {
+	id      string
+	puzzle  [9][9]int
+	in      [9][9]int
+	altered []validate.Cell
+	solved  bool
+}{
+	{id: "solved", puzzle: puzzle, in: working, solved: true},
+	{id: "not started", puzzle: withDead, in: withDead},
+	{id: "altered clue", puzzle: withDead, in: working, altered: []validate.Cell{
+		{0, 1}, {0, 2}, {0, 3}, {0, 4}, {0, 5}, {0, 6}, {0, 7}, {0, 8}, {4, 0},
+	}},
+	{id: "removed clue", puzzle: working, in: withEmptyAndOutOfRange, altered: []validate.Cell{
+		{0, 0}, {4, 4}, {8, 8},
+	}},
+}
+
+func TestSubmission(t *testing.T) {
+	for _, test := range submissionTests {
+		out := validate.Submission(test.puzzle, test.in)
+		if !reflect.DeepEqual(test.altered, out.Altered) {
+			t.Errorf("unexpected altered cells for %s:\n%v\n%v\n", test.id, test.altered, out.Altered)
+		}
+		if test.solved != out.Solved() {
+			t.Errorf("unexpected solved bool for %s: %v", test.id, out.Solved())
+		}
+	}
+}